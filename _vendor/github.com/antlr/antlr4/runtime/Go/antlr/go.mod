@@ -0,0 +1,3 @@
+module github.com/antlr/antlr4/runtime/Go/antlr
+
+go 1.16
@@ -0,0 +1,5 @@
+module github.com/stoewer/go-strcase
+
+go 1.11
+
+require github.com/stretchr/testify v1.5.1
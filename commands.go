@@ -0,0 +1,338 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Rakanixu/k8-cid/deployer"
+	"github.com/Rakanixu/k8-cid/utils"
+)
+
+// command is a subcommand of the CLI, with its own flags
+type command struct {
+	name string
+	// args the command takes after its flags
+	args    string
+	summary string
+	flags   func(fs *flag.FlagSet, o *options)
+	run     func(o *options, args []string) error
+}
+
+var commands = []*command{
+	{
+		name:    utils.CREATE_RESOURCE,
+		args:    "-repos repo=commit...",
+		summary: "Create the environment of the given repo commits",
+		flags: func(fs *flag.FlagSet, o *options) {
+			clusterFlags(fs, o)
+			reposFlags(fs, o)
+			settingsFlags(fs, o)
+			validationFlags(fs, o)
+			pinFlags(fs, o)
+			createFlags(fs, o)
+		},
+		run: runCreate,
+	},
+	{
+		name:    utils.DELETE_RESOURCE,
+		args:    "-repos repo=commit...",
+		summary: "Delete the environment of the given repo commits",
+		flags: func(fs *flag.FlagSet, o *options) {
+			clusterFlags(fs, o)
+			reposFlags(fs, o)
+			settingsFlags(fs, o)
+		},
+		run: func(o *options, args []string) error {
+			d, err := o.environment(args, false)
+			if err != nil {
+				return err
+			}
+			return d.Delete()
+		},
+	},
+	{
+		name:    utils.LIST_RESOURCE,
+		summary: "List the environments of the cluster",
+		flags:   clusterFlags,
+		run: func(o *options, args []string) error {
+			if len(args) > 0 {
+				return usageError{fmt.Sprintf("unexpected arguments %s", strings.Join(args, " "))}
+			}
+			clientset, err := o.client()
+			if err != nil {
+				return err
+			}
+			return deployer.List(clientset, os.Stdout)
+		},
+	},
+	{
+		name:    utils.DESCRIBE_RESOURCE,
+		args:    "-repos repo=commit... | <namespace>",
+		summary: "Describe the deployments, services and ingresses of an environment",
+		flags: func(fs *flag.FlagSet, o *options) {
+			clusterFlags(fs, o)
+			reposFlags(fs, o)
+		},
+		run: func(o *options, args []string) error {
+			d, err := o.environment(args, true)
+			if err != nil {
+				return err
+			}
+			return d.Describe(os.Stdout)
+		},
+	},
+	{
+		name:    utils.RENDER_RESOURCE,
+		args:    "-repos repo=commit...",
+		summary: "Print the objects of the environment as YAML, without creating them",
+		flags: func(fs *flag.FlagSet, o *options) {
+			clusterFlags(fs, o)
+			reposFlags(fs, o)
+			settingsFlags(fs, o)
+			pinFlags(fs, o)
+		},
+		run: func(o *options, args []string) error {
+			// Progress and warnings go to stderr, so stdout only carries the manifests
+			stdout := os.Stdout
+			os.Stdout = os.Stderr
+			defer func() { os.Stdout = stdout }()
+
+			d, err := o.environment(args, false)
+			if err != nil {
+				return err
+			}
+			if o.pinDigests {
+				if err := d.PinDigests(); err != nil {
+					return err
+				}
+			}
+			return d.Render(stdout)
+		},
+	},
+	{
+		name:    utils.VALIDATE_RESOURCE,
+		args:    "-repos repo=commit...",
+		summary: "Validate the manifests and objects of the environment",
+		flags: func(fs *flag.FlagSet, o *options) {
+			clusterFlags(fs, o)
+			reposFlags(fs, o)
+			settingsFlags(fs, o)
+			validationFlags(fs, o)
+		},
+		run: func(o *options, args []string) error {
+			d, err := o.environment(args, false)
+			if err != nil {
+				return err
+			}
+			return d.Validate()
+		},
+	},
+	{
+		name:    utils.CONNECT_RESOURCE,
+		args:    "-repos repo=commit... | <namespace>",
+		summary: "Port-forward the services of an environment with kubectl",
+		flags: func(fs *flag.FlagSet, o *options) {
+			clusterFlags(fs, o)
+			reposFlags(fs, o)
+			fs.StringVar(&o.services, "services", "", "(optional) comma separated services to connect to, all of them by default")
+		},
+		run: func(o *options, args []string) error {
+			d, err := o.environment(args, true)
+			if err != nil {
+				return err
+			}
+			return d.Connect(o.kubeconfig, split(o.services))
+		},
+	},
+	{
+		name:    utils.LOGS_RESOURCE,
+		args:    "-repos repo=commit... | <namespace>",
+		summary: "Stream the logs of the components of an environment",
+		flags: func(fs *flag.FlagSet, o *options) {
+			clusterFlags(fs, o)
+			reposFlags(fs, o)
+			fs.StringVar(&o.components, "components", "", "(optional) comma separated components to get logs from, all of them by default")
+			fs.DurationVar(&o.since, "since", 0, "(optional) only return logs newer than a relative duration like 5s, 2m, or 3h")
+			fs.BoolVar(&o.previous, "previous", false, "(optional) print the logs of the previous instance of restarted containers")
+		},
+		run: func(o *options, args []string) error {
+			d, err := o.environment(args, true)
+			if err != nil {
+				return err
+			}
+			return d.Logs(split(o.components), o.since, o.previous)
+		},
+	},
+	{
+		name:    utils.COLLECT_RESOURCE,
+		args:    "-repos repo=commit... | <namespace>",
+		summary: "Collect a diagnostics bundle of an environment",
+		flags: func(fs *flag.FlagSet, o *options) {
+			clusterFlags(fs, o)
+			reposFlags(fs, o)
+			outputFlags(fs, o)
+		},
+		run: func(o *options, args []string) error {
+			d, err := o.environment(args, true)
+			if err != nil {
+				return err
+			}
+			_, err = d.Collect(o.output)
+			return err
+		},
+	},
+	{
+		name: utils.CONFIG_RESOURCE,
+		args: "show | validate | add-component <repo> <component>... | remove-component <repo> <component>... |\n" +
+			"         set-repo <repo> <component>,... [<repo> <component>,...]... | rename <repo> <name> | publish [settings-file]",
+		summary: "Show, validate, edit or publish the repos and settings",
+		flags:   clusterFlags,
+		run:     runConfig,
+	},
+}
+
+func runCreate(o *options, args []string) error {
+	d, err := o.environment(args, false)
+	if err != nil {
+		return err
+	}
+
+	if o.pinDigests {
+		if err := d.PinDigests(); err != nil {
+			return err
+		}
+	}
+	if err := d.Validate(); err != nil {
+		return err
+	}
+	if err := d.CheckPolicy(); err != nil {
+		return err
+	}
+	if !o.skipPreflight {
+		if err := d.Preflight(); err != nil {
+			return err
+		}
+	}
+	if o.verifyImages && !o.pinDigests {
+		if err := d.VerifyImages(); err != nil {
+			return err
+		}
+	}
+	if err := d.Create(); err != nil {
+		return err
+	}
+
+	if o.wait > 0 {
+		if err := d.Wait(o.wait); err != nil {
+			if o.collectOnFailure {
+				if _, cErr := d.Collect(o.output); cErr != nil {
+					fmt.Println("Could not collect diagnostics: ", cErr)
+				}
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// runConfig runs config show, validate, add-component, remove-component, set-repo,
+// rename and publish. Only publish needs the cluster, the rest use the configuration
+// it shares as last cached.
+func runConfig(o *options, args []string) error {
+	if len(args) == 0 {
+		return usageError{"missing config command"}
+	}
+	o.useCluster()
+
+	switch {
+	case args[0] == "show" && len(args) == 1:
+		return utils.ShowConfig(os.Stdout)
+	case args[0] == "validate" && len(args) == 1:
+		if err := utils.ValidateConfig(); err != nil {
+			return err
+		}
+		fmt.Println("Configuration is valid")
+		return nil
+	case args[0] == "publish" && len(args) <= 2:
+		clientset, err := o.client()
+		if err != nil {
+			return err
+		}
+		settingsFile := ""
+		if len(args) == 2 {
+			settingsFile = args[1]
+		}
+		return deployer.PublishSharedConfig(clientset, settingsFile)
+	case args[0] == "add-component" && len(args) >= 3:
+		if err := utils.AddComponents(args[1], args[2:]); err != nil {
+			return err
+		}
+	case args[0] == "remove-component" && len(args) >= 3:
+		if err := utils.RemoveComponents(args[1], args[2:]); err != nil {
+			return err
+		}
+	case args[0] == "set-repo" && len(args) >= 3 && len(args)%2 == 1:
+		// Every repo is set at once, so components can move between them
+		set := map[string][]string{}
+		for k := 1; k < len(args); k += 2 {
+			if _, ok := set[args[k]]; ok {
+				return usageError{fmt.Sprintf("repo %s given twice", args[k])}
+			}
+			set[args[k]] = strings.Split(args[k+1], ",")
+		}
+		if err := utils.SetRepos(set); err != nil {
+			return err
+		}
+	case args[0] == "rename" && len(args) == 3:
+		if err := utils.RenameRepo(args[1], args[2]); err != nil {
+			return err
+		}
+	default:
+		return usageError{fmt.Sprintf("invalid config command %s", strings.Join(args, " "))}
+	}
+	fmt.Println("Configuration file saved!")
+
+	return nil
+}
+
+func findCommand(name string) *command {
+	for _, c := range commands {
+		if c.name == name {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// flagSet returns the flag set of a command, defaulted from the environment
+func (c *command) flagSet(o *options) *flag.FlagSet {
+	fs := flag.NewFlagSet(c.name, flag.ExitOnError)
+	c.flags(fs, o)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "%s\n\nUsage:\n  k8-cid %s [flags] %s\n\nFlags:\n", c.summary, c.name, c.args)
+		fs.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\nEvery flag defaults to its %s<FLAG> environment variable, e.g. %s.\n", envPrefix, envName("kubeconfig"))
+	}
+
+	return fs
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage:\n  k8-cid <command> [flags] [arguments]\n\nCommands:\n")
+	for _, c := range commands {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", c.name, c.summary)
+	}
+	fmt.Fprintf(os.Stderr, "\nRun k8-cid help <command> for the flags of a command.\n")
+}
+
+func split(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	return strings.Split(s, ",")
+}
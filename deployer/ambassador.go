@@ -0,0 +1,149 @@
+package deployer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Rakanixu/k8-cid/image"
+	"github.com/Rakanixu/k8-cid/utils"
+	yaml "gopkg.in/yaml.v2"
+
+	apiv1 "k8s.io/api/core/v1"
+)
+
+const (
+	ambassadorImage            = "datawire/ambassador"
+	ambassadorConfigAnnotation = "getambassador.io/config"
+	ambassadorIDKey            = "ambassador_id"
+)
+
+// scopeAmbassador makes the Ambassador of an environment route its own namespace
+// only. Ambassador is told to watch its namespace alone and to only serve the
+// configuration annotated with the environment ambassador_id, which is set on the
+// annotations of every service. Its cluster role is replaced with a role on the
+// namespace when the rules allow it.
+func (d *Deployer) scopeAmbassador() error {
+	if d.Settings.Ambassador.Unscoped {
+		return nil
+	}
+
+	found := false
+	for _, deployment := range d.deployments {
+		c := d.ambassadorContainer(deployment)
+		if c == nil {
+			continue
+		}
+		found = true
+
+		setEnv(c, "AMBASSADOR_SINGLE_NAMESPACE", "true")
+		setEnv(c, "AMBASSADOR_ID", d.GetNamespace())
+		fmt.Printf("Scoped ambassador %s to namespace %s\n", deployment.component, d.GetNamespace())
+
+		if d.downgradeClusterRole(deployment) {
+			fmt.Printf("Replaced cluster role of %s with role %s\n", deployment.component, deployment.k8sRole.GetObjectMeta().GetName())
+		}
+	}
+	if !found {
+		return nil
+	}
+
+	for _, deployment := range d.deployments {
+		annotations := deployment.k8sService.GetObjectMeta().GetAnnotations()
+		config, ok := annotations[ambassadorConfigAnnotation]
+		if !ok {
+			continue
+		}
+
+		scoped, err := setAmbassadorID(config, d.GetNamespace())
+		if err != nil {
+			return fmt.Errorf("%s: invalid %s annotation: %s", deployment.component, ambassadorConfigAnnotation, err)
+		}
+		annotations[ambassadorConfigAnnotation] = scoped
+	}
+
+	return nil
+}
+
+// ambassadorContainer returns the Ambassador container of a component, if it runs one
+func (d *Deployer) ambassadorContainer(deployment *deployment) *apiv1.Container {
+	containers := deployment.k8sDeployment.Spec.Template.Spec.Containers
+	for k, c := range containers {
+		ref, err := image.Parse(c.Image)
+		if err == nil && strings.HasSuffix(ref.Path, ambassadorImage) {
+			return &containers[k]
+		}
+	}
+
+	if utils.Find(d.Settings.Ambassador.Components, deployment.component) != -1 && len(containers) > 0 {
+		return &containers[0]
+	}
+
+	return nil
+}
+
+// setEnv sets an environment variable of a container, replacing it if already set
+func setEnv(c *apiv1.Container, name string, value string) {
+	for k, v := range c.Env {
+		if v.Name == name {
+			c.Env[k] = apiv1.EnvVar{Name: name, Value: value}
+			return
+		}
+	}
+	c.Env = append(c.Env, apiv1.EnvVar{Name: name, Value: value})
+}
+
+// setAmbassadorID sets the ambassador_id of every document of an Ambassador
+// configuration annotation
+func setAmbassadorID(config string, id string) (string, error) {
+	var docs []string
+
+	for _, doc := range splitYAMLDocuments(config) {
+		var m yaml.MapSlice
+		if err := yaml.Unmarshal([]byte(doc), &m); err != nil {
+			return "", err
+		}
+
+		set := false
+		for k, v := range m {
+			if v.Key == ambassadorIDKey {
+				m[k].Value = id
+				set = true
+			}
+		}
+		if !set {
+			m = append(m, yaml.MapItem{Key: ambassadorIDKey, Value: id})
+		}
+
+		b, err := yaml.Marshal(m)
+		if err != nil {
+			return "", err
+		}
+		docs = append(docs, "---\n"+string(b))
+	}
+
+	return strings.Join(docs, ""), nil
+}
+
+// splitYAMLDocuments splits a multi document YAML on its --- separators, dropping empty documents
+func splitYAMLDocuments(s string) []string {
+	var docs []string
+	var current []string
+
+	flush := func() {
+		doc := strings.Join(current, "\n")
+		if strings.TrimSpace(doc) != "" {
+			docs = append(docs, doc)
+		}
+		current = nil
+	}
+	for _, line := range strings.Split(s, "\n") {
+		if strings.TrimRight(line, " ") == "---" {
+			flush()
+			continue
+		}
+		current = append(current, line)
+	}
+	flush()
+
+	return docs
+}
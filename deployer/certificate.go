@@ -0,0 +1,210 @@
+package deployer
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	defaultValidity           = 30 * 24 * time.Hour
+	defaultCertManagerVersion = "certmanager.k8s.io/v1alpha1"
+	defaultIssuerKind         = "ClusterIssuer"
+)
+
+// issueCertificate signs a certificate for the hosts with the local CA, returning
+// it as a TLS secret of the environment namespace
+func (d *Deployer) issueCertificate(hosts []string) (*apiv1.Secret, error) {
+	tls := d.Settings.Ingress.TLS
+	if tls.CACert == "" || tls.CAKey == "" {
+		return nil, fmt.Errorf("Ingress TLS from a local CA needs the CA certificate and key files")
+	}
+
+	validity := defaultValidity
+	if tls.Validity != "" {
+		v, err := time.ParseDuration(tls.Validity)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid certificate validity %s: %s", tls.Validity, err)
+		}
+		validity = v
+	}
+
+	caCert, caKey, err := readCA(tls.CACert, tls.CAKey)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			CommonName:   hosts[0],
+			Organization: []string{d.GetNamespace()},
+		},
+		DNSNames:    hosts,
+		NotBefore:   now.Add(-5 * time.Minute),
+		NotAfter:    now.Add(validity),
+		KeyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, caCert, key.Public(), caKey)
+	if err != nil {
+		return nil, err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	// Serve the chain up to the CA
+	chain := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	chain = append(chain, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caCert.Raw})...)
+
+	return &apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      tlsSecretName,
+			Namespace: d.GetNamespace(),
+			Labels: map[string]string{
+				managedByLabel: managedByValue,
+			},
+		},
+		Type: apiv1.SecretTypeTLS,
+		Data: map[string][]byte{
+			apiv1.TLSCertKey:       chain,
+			apiv1.TLSPrivateKeyKey: pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		},
+	}, nil
+}
+
+// readCA reads the PEM certificate and key of the local CA
+func readCA(certFile string, keyFile string) (*x509.Certificate, crypto.Signer, error) {
+	b, err := ioutil.ReadFile(certFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, nil, fmt.Errorf("No PEM certificate in %s", certFile)
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %s", certFile, err)
+	}
+	if !cert.IsCA {
+		return nil, nil, fmt.Errorf("%s is not a CA certificate", certFile)
+	}
+
+	b, err = ioutil.ReadFile(keyFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	block, _ = pem.Decode(b)
+	if block == nil {
+		return nil, nil, fmt.Errorf("No PEM key in %s", keyFile)
+	}
+
+	var key interface{}
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %s", keyFile, err)
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, nil, fmt.Errorf("%s: unsupported key type", keyFile)
+	}
+
+	return cert, signer, nil
+}
+
+// certificateSpec returns a cert-manager Certificate for the hosts, issued into the TLS secret
+func (d *Deployer) certificateSpec(hosts []string) map[string]interface{} {
+	tls := d.Settings.Ingress.TLS
+	apiVersion := tls.APIVersion
+	if apiVersion == "" {
+		apiVersion = defaultCertManagerVersion
+	}
+	issuerKind := tls.IssuerKind
+	if issuerKind == "" {
+		issuerKind = defaultIssuerKind
+	}
+
+	return map[string]interface{}{
+		"apiVersion": apiVersion,
+		"kind":       "Certificate",
+		"metadata": map[string]interface{}{
+			"name":      tlsSecretName,
+			"namespace": d.GetNamespace(),
+			"labels": map[string]string{
+				managedByLabel: managedByValue,
+			},
+		},
+		"spec": map[string]interface{}{
+			"secretName": tlsSecretName,
+			"commonName": hosts[0],
+			"dnsNames":   hosts,
+			"issuerRef": map[string]string{
+				"name": tls.Issuer,
+				"kind": issuerKind,
+			},
+		},
+	}
+}
+
+// certificatesPath is the API path of the cert-manager Certificates of the environment
+func (d *Deployer) certificatesPath() string {
+	return fmt.Sprintf("/apis/%s/namespaces/%s/certificates", d.certificate["apiVersion"], d.GetNamespace())
+}
+
+// createCertificate creates the cert-manager Certificate, which has no typed client
+func (d *Deployer) createCertificate() error {
+	if d.Settings.Ingress.TLS.Issuer == "" {
+		return fmt.Errorf("Ingress TLS through cert-manager needs an issuer")
+	}
+
+	body, err := json.Marshal(d.certificate)
+	if err != nil {
+		return err
+	}
+
+	return d.Client.CoreV1().RESTClient().Post().
+		AbsPath(d.certificatesPath()).
+		SetHeader("Content-Type", "application/json").
+		Body(body).
+		Do().
+		Error()
+}
+
+func (d *Deployer) deleteCertificate() error {
+	return d.Client.CoreV1().RESTClient().Delete().
+		AbsPath(d.certificatesPath(), tlsSecretName).
+		Do().
+		Error()
+}
@@ -0,0 +1,190 @@
+package deployer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Rakanixu/k8-cid/utils"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// testResources are the collections testCluster lists instead of getting
+var testResources = map[string]bool{
+	"namespaces": true, "nodes": true, "pods": true, "secrets": true, "services": true,
+	"events": true, "deployments": true, "configmaps": true, "selfsubjectaccessreviews": true,
+}
+
+// testCluster is an in-memory API server keeping objects by their API path, e.g.
+// /api/v1/namespaces/env/secrets/dockdev. It gets, lists by label selector, creates
+// and deletes them, enough to drive a real clientset.
+type testCluster struct {
+	sync.Mutex
+	objects map[string]map[string]interface{}
+	// requests are the method and path of every request received
+	requests []string
+}
+
+func newTestCluster() *testCluster {
+	return &testCluster{objects: map[string]map[string]interface{}{}}
+}
+
+// add stores a typed object at an API path
+func (c *testCluster) add(t *testing.T, p string, obj interface{}) {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := map[string]interface{}{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		t.Fatal(err)
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	c.objects[p] = m
+}
+
+// get decodes the object at an API path into obj, returning whether it exists
+func (c *testCluster) get(t *testing.T, p string, obj interface{}) bool {
+	c.Lock()
+	m, ok := c.objects[p]
+	c.Unlock()
+	if !ok {
+		return false
+	}
+
+	b, _ := json.Marshal(m)
+	if err := json.Unmarshal(b, obj); err != nil {
+		t.Fatal(err)
+	}
+
+	return true
+}
+
+// paths returns the sorted paths of the objects stored under a prefix
+func (c *testCluster) paths(prefix string) []string {
+	c.Lock()
+	defer c.Unlock()
+
+	var paths []string
+	for p := range c.objects {
+		if strings.HasPrefix(p, prefix) {
+			paths = append(paths, p)
+		}
+	}
+	sort.Strings(paths)
+
+	return paths
+}
+
+func (c *testCluster) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	c.Lock()
+	defer c.Unlock()
+	c.requests = append(c.requests, r.Method+" "+r.URL.Path)
+	w.Header().Set("Content-Type", "application/json")
+
+	p := strings.TrimSuffix(r.URL.Path, "/")
+	collection := testResources[path.Base(p)]
+
+	switch {
+	case r.Method == "GET" && collection:
+		selector, err := labels.Parse(r.URL.Query().Get("labelSelector"))
+		if err != nil {
+			testStatus(w, http.StatusBadRequest, "BadRequest")
+			return
+		}
+		items := []interface{}{}
+		for _, k := range sortedObjectPaths(c.objects) {
+			if path.Dir(k) != p {
+				continue
+			}
+			meta, _ := c.objects[k]["metadata"].(map[string]interface{})
+			set := labels.Set{}
+			l, _ := meta["labels"].(map[string]interface{})
+			for lk, lv := range l {
+				set[lk], _ = lv.(string)
+			}
+			if selector.Matches(set) {
+				items = append(items, c.objects[k])
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"metadata": map[string]interface{}{}, "items": items})
+	case r.Method == "GET":
+		obj, ok := c.objects[p]
+		if !ok {
+			testStatus(w, http.StatusNotFound, "NotFound")
+			return
+		}
+		json.NewEncoder(w).Encode(obj)
+	case r.Method == "POST" && collection:
+		b, _ := ioutil.ReadAll(r.Body)
+		obj := map[string]interface{}{}
+		if err := json.Unmarshal(b, &obj); err != nil {
+			testStatus(w, http.StatusBadRequest, "BadRequest")
+			return
+		}
+		meta, _ := obj["metadata"].(map[string]interface{})
+		name, _ := meta["name"].(string)
+		if _, ok := c.objects[p+"/"+name]; ok {
+			testStatus(w, http.StatusConflict, "AlreadyExists")
+			return
+		}
+		c.objects[p+"/"+name] = obj
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(obj)
+	case r.Method == "DELETE":
+		if _, ok := c.objects[p]; !ok {
+			testStatus(w, http.StatusNotFound, "NotFound")
+			return
+		}
+		delete(c.objects, p)
+		testStatus(w, http.StatusOK, "")
+	default:
+		testStatus(w, http.StatusMethodNotAllowed, "MethodNotAllowed")
+	}
+}
+
+func testStatus(w http.ResponseWriter, code int, reason string) {
+	status := "Success"
+	if code >= 300 {
+		status = "Failure"
+	}
+	w.WriteHeader(code)
+	fmt.Fprintf(w, `{"kind": "Status", "apiVersion": "v1", "status": %q, "reason": %q, "code": %d}`, status, reason, code)
+}
+
+func sortedObjectPaths(objects map[string]map[string]interface{}) []string {
+	var paths []string
+	for p := range objects {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	return paths
+}
+
+// newTestDeployer returns a deployer of the namespace env talking to the cluster
+func newTestDeployer(t *testing.T, cluster *testCluster) (*Deployer, func()) {
+	server := httptest.NewServer(cluster)
+	client, err := kubernetes.NewForConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		server.Close()
+		t.Fatal(err)
+	}
+
+	d := &Deployer{Client: client, Settings: &utils.Settings{}}
+	d.SetNamespace("env")
+
+	return d, server.Close
+}
@@ -0,0 +1,377 @@
+package deployer
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// bundle writes the files of a diagnostics tarball
+type bundle struct {
+	tw  *tar.Writer
+	dir string
+	now time.Time
+	// errors of the sections that could not be collected
+	errors []string
+}
+
+func (b *bundle) add(name string, data []byte) error {
+	if err := b.tw.WriteHeader(&tar.Header{
+		Name:    b.dir + "/" + name,
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: b.now,
+	}); err != nil {
+		return err
+	}
+	_, err := b.tw.Write(data)
+
+	return err
+}
+
+func (b *bundle) addYAML(name string, obj interface{}) error {
+	y, err := yaml.Marshal(obj)
+	if err != nil {
+		return err
+	}
+
+	return b.add(name, y)
+}
+
+// skip records a section that could not be collected, e.g. for lack of permissions,
+// so the rest of the bundle still is. Skipped sections are listed on errors.txt.
+func (b *bundle) skip(section string, err error) {
+	fmt.Printf("Could not collect %s: %s\n", section, err)
+	b.errors = append(b.errors, fmt.Sprintf("%s: %s", section, err))
+}
+
+// Collect writes a diagnostics bundle of the environment to a gzipped tarball: the
+// rendered manifests, the live objects, the namespace events, a description of every
+// pod and the current and previous logs of every container. An empty path writes
+// <namespace>-<timestamp>.tar.gz on the working directory.
+// Sections that cannot be read are skipped, the bundle is only removed when it
+// cannot be written.
+// Returns the path of the written bundle.
+func (d *Deployer) Collect(path string) (string, error) {
+	now := time.Now()
+	if path == "" {
+		path = fmt.Sprintf("%s-%s.tar.gz", d.GetNamespace(), now.Format("20060102-150405"))
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+
+	fmt.Println("Collecting diagnostics of namespace ", d.GetNamespace())
+	if err := d.writeBundle(f, now); err != nil {
+		f.Close()
+		os.Remove(path)
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(path)
+		return "", err
+	}
+	fmt.Println("Diagnostics written to ", path)
+
+	return path, nil
+}
+
+func (d *Deployer) writeBundle(w io.Writer, now time.Time) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+	b := &bundle{tw: tw, dir: d.GetNamespace(), now: now}
+
+	if err := d.collect(b); err != nil {
+		return err
+	}
+	if len(b.errors) > 0 {
+		if err := b.add("errors.txt", []byte(strings.Join(b.errors, "\n")+"\n")); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+
+	return gw.Close()
+}
+
+// collect adds every section to the bundle. Only failures to write the bundle are
+// returned, sections failing to be read are skipped.
+func (d *Deployer) collect(b *bundle) error {
+	ns := d.GetNamespace()
+
+	// Rendered manifests are only known when the environment was initialised from its repos
+	if len(d.deployments) > 0 {
+		var rendered bytes.Buffer
+		if err := d.Render(&rendered); err != nil {
+			b.skip("rendered manifests", err)
+		} else if err := b.add("manifests.yaml", rendered.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	if live, err := d.Client.CoreV1().Namespaces().Get(ns, metav1.GetOptions{}); err != nil {
+		b.skip("namespace", err)
+	} else {
+		live.TypeMeta = metav1.TypeMeta{Kind: "Namespace", APIVersion: "v1"}
+		if err := b.addYAML("live/namespace.yaml", live); err != nil {
+			return err
+		}
+	}
+
+	if deployments, err := d.Client.AppsV1().Deployments(ns).List(metav1.ListOptions{}); err != nil {
+		b.skip("deployments", err)
+	} else {
+		for _, v := range deployments.Items {
+			v.TypeMeta = metav1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"}
+			if err := b.addYAML("live/deployments/"+v.GetObjectMeta().GetName()+".yaml", v); err != nil {
+				return err
+			}
+		}
+	}
+
+	if replicaSets, err := d.Client.AppsV1().ReplicaSets(ns).List(metav1.ListOptions{}); err != nil {
+		b.skip("replicasets", err)
+	} else {
+		for _, v := range replicaSets.Items {
+			v.TypeMeta = metav1.TypeMeta{Kind: "ReplicaSet", APIVersion: "apps/v1"}
+			if err := b.addYAML("live/replicasets/"+v.GetObjectMeta().GetName()+".yaml", v); err != nil {
+				return err
+			}
+		}
+	}
+
+	if services, err := d.Client.CoreV1().Services(ns).List(metav1.ListOptions{}); err != nil {
+		b.skip("services", err)
+	} else {
+		for _, v := range services.Items {
+			v.TypeMeta = metav1.TypeMeta{Kind: "Service", APIVersion: "v1"}
+			if err := b.addYAML("live/services/"+v.GetObjectMeta().GetName()+".yaml", v); err != nil {
+				return err
+			}
+		}
+	}
+
+	if endpoints, err := d.Client.CoreV1().Endpoints(ns).List(metav1.ListOptions{}); err != nil {
+		b.skip("endpoints", err)
+	} else {
+		for _, v := range endpoints.Items {
+			v.TypeMeta = metav1.TypeMeta{Kind: "Endpoints", APIVersion: "v1"}
+			if err := b.addYAML("live/endpoints/"+v.GetObjectMeta().GetName()+".yaml", v); err != nil {
+				return err
+			}
+		}
+	}
+
+	if svcAccounts, err := d.Client.CoreV1().ServiceAccounts(ns).List(metav1.ListOptions{}); err != nil {
+		b.skip("serviceaccounts", err)
+	} else {
+		for _, v := range svcAccounts.Items {
+			v.TypeMeta = metav1.TypeMeta{Kind: "ServiceAccount", APIVersion: "v1"}
+			if err := b.addYAML("live/serviceaccounts/"+v.GetObjectMeta().GetName()+".yaml", v); err != nil {
+				return err
+			}
+		}
+	}
+
+	if configMaps, err := d.Client.CoreV1().ConfigMaps(ns).List(metav1.ListOptions{}); err != nil {
+		b.skip("configmaps", err)
+	} else {
+		for _, v := range configMaps.Items {
+			v.TypeMeta = metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"}
+			if err := b.addYAML("live/configmaps/"+v.GetObjectMeta().GetName()+".yaml", v); err != nil {
+				return err
+			}
+		}
+	}
+
+	if roles, err := d.Client.RbacV1().Roles(ns).List(metav1.ListOptions{}); err != nil {
+		b.skip("roles", err)
+	} else {
+		for _, v := range roles.Items {
+			v.TypeMeta = metav1.TypeMeta{Kind: "Role", APIVersion: "rbac.authorization.k8s.io/v1"}
+			if err := b.addYAML("live/roles/"+v.GetObjectMeta().GetName()+".yaml", v); err != nil {
+				return err
+			}
+		}
+	}
+
+	if roleBindings, err := d.Client.RbacV1().RoleBindings(ns).List(metav1.ListOptions{}); err != nil {
+		b.skip("rolebindings", err)
+	} else {
+		for _, v := range roleBindings.Items {
+			v.TypeMeta = metav1.TypeMeta{Kind: "RoleBinding", APIVersion: "rbac.authorization.k8s.io/v1"}
+			if err := b.addYAML("live/rolebindings/"+v.GetObjectMeta().GetName()+".yaml", v); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Cluster scoped objects created for the environment
+	for _, deployment := range d.deployments {
+		if n := deployment.k8sClusterRole.GetObjectMeta().GetName(); n != "" {
+			if v, err := d.Client.RbacV1().ClusterRoles().Get(n, metav1.GetOptions{}); err != nil {
+				b.skip("clusterrole "+n, err)
+			} else {
+				v.TypeMeta = metav1.TypeMeta{Kind: "ClusterRole", APIVersion: "rbac.authorization.k8s.io/v1"}
+				if err := b.addYAML("live/clusterroles/"+n+".yaml", v); err != nil {
+					return err
+				}
+			}
+		}
+		if n := deployment.k8sClusterRoleBinding.GetObjectMeta().GetName(); n != "" {
+			if v, err := d.Client.RbacV1().ClusterRoleBindings().Get(n, metav1.GetOptions{}); err != nil {
+				b.skip("clusterrolebinding "+n, err)
+			} else {
+				v.TypeMeta = metav1.TypeMeta{Kind: "ClusterRoleBinding", APIVersion: "rbac.authorization.k8s.io/v1"}
+				if err := b.addYAML("live/clusterrolebindings/"+n+".yaml", v); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	var events []apiv1.Event
+	if list, err := d.Client.CoreV1().Events(ns).List(metav1.ListOptions{}); err != nil {
+		b.skip("events", err)
+	} else {
+		events = list.Items
+		sort.Slice(events, func(i, j int) bool {
+			return events[i].LastTimestamp.Before(&events[j].LastTimestamp)
+		})
+		if err := b.add("events.txt", []byte(formatEvents(events))); err != nil {
+			return err
+		}
+	}
+
+	pods, err := d.Client.CoreV1().Pods(ns).List(metav1.ListOptions{})
+	if err != nil {
+		b.skip("pods", err)
+		return nil
+	}
+	for k := range pods.Items {
+		pod := &pods.Items[k]
+		pod.TypeMeta = metav1.TypeMeta{Kind: "Pod", APIVersion: "v1"}
+		name := pod.GetObjectMeta().GetName()
+
+		if err := b.addYAML("live/pods/"+name+".yaml", pod); err != nil {
+			return err
+		}
+		if err := b.add("describe/"+name+".txt", []byte(describePod(pod, events))); err != nil {
+			return err
+		}
+
+		for _, cs := range pod.Status.ContainerStatuses {
+			if logs, err := d.containerLogs(pod, cs.Name, false); err != nil {
+				b.skip(fmt.Sprintf("logs of %s/%s", name, cs.Name), err)
+			} else if err := b.add(fmt.Sprintf("logs/%s/%s.log", name, cs.Name), logs); err != nil {
+				return err
+			}
+			if cs.RestartCount == 0 && cs.LastTerminationState.Terminated == nil {
+				continue
+			}
+			if logs, err := d.containerLogs(pod, cs.Name, true); err != nil {
+				b.skip(fmt.Sprintf("previous logs of %s/%s", name, cs.Name), err)
+			} else if err := b.add(fmt.Sprintf("logs/%s/%s.previous.log", name, cs.Name), logs); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (d *Deployer) containerLogs(pod *apiv1.Pod, container string, previous bool) ([]byte, error) {
+	r, err := d.Client.CoreV1().Pods(pod.GetObjectMeta().GetNamespace()).GetLogs(pod.GetObjectMeta().GetName(), &apiv1.PodLogOptions{
+		Container: container,
+		Previous:  previous,
+	}).Stream()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	return ioutil.ReadAll(r)
+}
+
+func formatEvents(events []apiv1.Event) string {
+	var s strings.Builder
+	for _, e := range events {
+		fmt.Fprintf(&s, "%s\t%s\t%s\t%s/%s\t%s\n",
+			e.LastTimestamp.Format(time.RFC3339), e.Type, e.Reason,
+			strings.ToLower(e.InvolvedObject.Kind), e.InvolvedObject.Name, e.Message)
+	}
+
+	return s.String()
+}
+
+// describePod gives a human readable summary of a pod, close to kubectl describe
+func describePod(pod *apiv1.Pod, events []apiv1.Event) string {
+	var s strings.Builder
+
+	fmt.Fprintf(&s, "Name:\t\t%s\n", pod.GetObjectMeta().GetName())
+	fmt.Fprintf(&s, "Namespace:\t%s\n", pod.GetObjectMeta().GetNamespace())
+	fmt.Fprintf(&s, "Node:\t\t%s\n", pod.Spec.NodeName)
+	fmt.Fprintf(&s, "Phase:\t\t%s\n", pod.Status.Phase)
+	if pod.Status.Reason != "" {
+		fmt.Fprintf(&s, "Reason:\t\t%s\n", pod.Status.Reason)
+	}
+	if pod.Status.Message != "" {
+		fmt.Fprintf(&s, "Message:\t%s\n", pod.Status.Message)
+	}
+
+	fmt.Fprintf(&s, "\nConditions:\n")
+	for _, c := range pod.Status.Conditions {
+		fmt.Fprintf(&s, "  %s\t%s\t%s\t%s\n", c.Type, c.Status, c.Reason, c.Message)
+	}
+
+	fmt.Fprintf(&s, "\nContainers:\n")
+	for _, cs := range pod.Status.ContainerStatuses {
+		fmt.Fprintf(&s, "  %s:\n", cs.Name)
+		fmt.Fprintf(&s, "    Image:\t%s\n", cs.Image)
+		fmt.Fprintf(&s, "    Ready:\t%t\n", cs.Ready)
+		fmt.Fprintf(&s, "    Restarts:\t%d\n", cs.RestartCount)
+		fmt.Fprintf(&s, "    State:\t%s\n", describeContainerState(cs.State))
+		if cs.LastTerminationState.Terminated != nil {
+			fmt.Fprintf(&s, "    Last State:\t%s\n", describeContainerState(cs.LastTerminationState))
+		}
+	}
+
+	fmt.Fprintf(&s, "\nEvents:\n")
+	for _, e := range events {
+		if e.InvolvedObject.Kind == "Pod" && e.InvolvedObject.Name == pod.GetObjectMeta().GetName() {
+			fmt.Fprintf(&s, "  %s\t%s\t%s\t%s\n", e.LastTimestamp.Format(time.RFC3339), e.Type, e.Reason, e.Message)
+		}
+	}
+
+	return s.String()
+}
+
+func describeContainerState(state apiv1.ContainerState) string {
+	switch {
+	case state.Running != nil:
+		return fmt.Sprintf("Running since %s", state.Running.StartedAt.Format(time.RFC3339))
+	case state.Waiting != nil:
+		return fmt.Sprintf("Waiting (%s) %s", state.Waiting.Reason, state.Waiting.Message)
+	case state.Terminated != nil:
+		return fmt.Sprintf("Terminated (%s) exit code %d %s", state.Terminated.Reason, state.Terminated.ExitCode, state.Terminated.Message)
+	}
+
+	return "Unknown"
+}
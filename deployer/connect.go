@@ -0,0 +1,185 @@
+package deployer
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/Rakanixu/k8-cid/utils"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const reconnectDelay = 2 * time.Second
+
+type portForward struct {
+	service string
+	env     string
+	local   int32
+	remote  int32
+}
+
+// Connect port-forwards every service of the environment namespace (or only the
+// given ones) to local ports. Forwards are restarted whenever they drop, e.g. when
+// the pods behind a service restart, until the process is interrupted.
+// Forwards are run by kubectl, which has to be on the PATH.
+func (d *Deployer) Connect(kubeconfig string, services []string) error {
+	if _, err := exec.LookPath("kubectl"); err != nil {
+		return fmt.Errorf("connect runs kubectl port-forward, install kubectl: %s", err)
+	}
+
+	svcs, err := d.Client.CoreV1().Services(d.GetNamespace()).List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	var selected []apiv1.Service
+	for _, v := range svcs.Items {
+		if len(services) == 0 || utils.Find(services, v.GetObjectMeta().GetName()) != -1 {
+			selected = append(selected, v)
+		}
+	}
+	if len(selected) == 0 {
+		return fmt.Errorf("No services to connect to on namespace %s", d.GetNamespace())
+	}
+
+	forwards := localPortMap(selected)
+
+	fmt.Println("# Environment", d.GetNamespace())
+	for _, v := range forwards {
+		fmt.Printf("%s=http://localhost:%d\n", v.env, v.local)
+	}
+	fmt.Println()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signals
+		fmt.Println("Closing port-forwards")
+		cancel()
+	}()
+
+	// One kubectl process per service, forwarding all its ports
+	var wg sync.WaitGroup
+	for _, svc := range selected {
+		var ports []string
+		for _, v := range forwards {
+			if v.service == svc.GetObjectMeta().GetName() {
+				ports = append(ports, fmt.Sprintf("%d:%d", v.local, v.remote))
+			}
+		}
+
+		wg.Add(1)
+		go func(name string, ports []string) {
+			defer wg.Done()
+			d.portForward(ctx, kubeconfig, name, ports)
+		}(svc.GetObjectMeta().GetName(), ports)
+	}
+	wg.Wait()
+
+	return nil
+}
+
+// portForward runs kubectl port-forward against a service, reconnecting until ctx is done.
+func (d *Deployer) portForward(ctx context.Context, kubeconfig string, service string, ports []string) {
+	args := []string{"--namespace", d.GetNamespace(), "port-forward", "svc/" + service}
+	if kubeconfig != "" {
+		args = append([]string{"--kubeconfig", kubeconfig}, args...)
+	}
+	args = append(args, ports...)
+
+	for {
+		fmt.Printf("Forwarding service %s %s\n", service, strings.Join(ports, " "))
+		cmd := exec.CommandContext(ctx, "kubectl", args...)
+		cmd.Stderr = os.Stderr
+		err := cmd.Run()
+
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		fmt.Printf("Port-forward to service %s closed (%v), reconnecting\n", service, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+// localPortMap assigns a local port to every service port. The container port is
+// preferred, privileged ports are moved above 10000 and collisions take the next free
+// port, walking services by name so the same environment always gets the same map.
+// Ports already listened on locally are skipped as well.
+func localPortMap(services []apiv1.Service) []portForward {
+	sort.Slice(services, func(i, j int) bool {
+		return services[i].GetObjectMeta().GetName() < services[j].GetObjectMeta().GetName()
+	})
+
+	var forwards []portForward
+	used := make(map[int32]bool)
+	for _, svc := range services {
+		name := svc.GetObjectMeta().GetName()
+		for k, p := range svc.Spec.Ports {
+			local := p.TargetPort.IntVal
+			if local == 0 {
+				local = p.Port
+			}
+			if local < 1024 {
+				local += 10000
+			}
+			for used[local] || !portFree(local) {
+				local++
+			}
+			used[local] = true
+
+			forwards = append(forwards, portForward{
+				service: name,
+				env:     envVarName(name, p, k),
+				local:   local,
+				remote:  p.Port,
+			})
+		}
+	}
+
+	return forwards
+}
+
+// portFree tells whether nothing listens on a local port
+func portFree(port int32) bool {
+	l, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	if err != nil {
+		return false
+	}
+	l.Close()
+
+	return true
+}
+
+// envVarName returns MERCURY_URL for the first port of a service, and
+// MERCURY_<PORT NAME>_URL for the rest of them.
+func envVarName(service string, p apiv1.ServicePort, index int) string {
+	name := service
+	if index > 0 {
+		if p.Name != "" {
+			name += "_" + p.Name
+		} else {
+			name += "_" + strconv.Itoa(int(p.Port))
+		}
+	}
+
+	return strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(name)) + "_URL"
+}
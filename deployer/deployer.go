@@ -10,25 +10,42 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	apiv1 "k8s.io/api/core/v1"
+	extv1beta1 "k8s.io/api/extensions/v1beta1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	yaml "k8s.io/apimachinery/pkg/util/yaml"
 	"k8s.io/client-go/kubernetes"
 )
 
 type Deployer struct {
 	Client      *kubernetes.Clientset
+	Settings    *utils.Settings
 	tags        []string
 	uuid        uuid.UUID
 	namespace   string
 	deployments []*deployment
+	// pins are the digests image tags were pinned to, by tagged image
+	pins map[string]string
+	// routes to the environment services through the shared gateway
+	routes        []route
+	routesService *apiv1.Service
+	routesIngress *extv1beta1.Ingress
+	// ingress exposes the annotated services on hostnames of the environment,
+	// certificate is the cert-manager Certificate of its TLS hosts, if any
+	ingress     *extv1beta1.Ingress
+	certificate map[string]interface{}
 }
 
 func NewDeployer(c *kubernetes.Clientset, t []string) (*Deployer, error) {
+	s, err := utils.ReadSettings()
+	if err != nil {
+		return nil, err
+	}
+
 	return &Deployer{
-		Client: c,
-		tags:   t,
-		uuid:   uuid.New(),
+		Client:   c,
+		Settings: s,
+		tags:     t,
+		uuid:     uuid.New(),
 	}, nil
 }
 
@@ -42,6 +59,11 @@ func (d *Deployer) Init() error {
 		commitTag := s[1]
 		namespace += repo + "-" + commitTag + "-"
 
+		// Environments routed through the shared gateway do not get their own
+		if d.Settings.Gateway.Shared && utils.Find(d.Settings.Gateway.Repos, repo) != -1 {
+			continue
+		}
+
 		for _, component := range reposMap[repo] {
 			d.deployments = append(d.deployments, newDeployment(component, repo, commitTag))
 		}
@@ -60,14 +82,46 @@ func (d *Deployer) Init() error {
 		return err
 	}
 
+	if err := d.generateRoles(); err != nil {
+		return err
+	}
+
+	if err := d.generateRoleBindings(); err != nil {
+		return err
+	}
+
 	if err := d.generateDeployment(); err != nil {
 		return err
 	}
 
+	if err := d.transformDeployments(); err != nil {
+		return err
+	}
+
 	if err := d.generateServices(); err != nil {
 		return err
 	}
 
+	if err := d.applyServicePolicy(); err != nil {
+		return err
+	}
+
+	if err := d.scopeAmbassador(); err != nil {
+		return err
+	}
+
+	if d.Settings.RBAC.DowngradeClusterRoles {
+		d.downgradeClusterRoles()
+	}
+
+	if err := d.generateRoutes(); err != nil {
+		return err
+	}
+
+	if err := d.generateIngress(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -76,20 +130,19 @@ func (d *Deployer) Create() error {
 	if err != nil {
 		return err
 	}
+	var secretsNamespaces []string
+
+	if err := d.reallocateNodePorts(); err != nil {
+		return err
+	}
 
 	for _, deployment := range d.deployments {
 		ns := deployment.k8sDeployment.GetObjectMeta().GetNamespace()
 
 		// Namespace does not exits
 		if utils.Find(liveNamespaces, ns) == -1 {
-			nsSpec := &apiv1.Namespace{
-				ObjectMeta: metav1.ObjectMeta{
-					Name: ns,
-				},
-			}
-
 			fmt.Println("Creating namespace ", ns)
-			_, err := d.Client.Core().Namespaces().Create(nsSpec)
+			_, err := d.Client.Core().Namespaces().Create(d.namespaceSpec())
 			if err != nil {
 				return err
 			}
@@ -100,6 +153,14 @@ func (d *Deployer) Create() error {
 			}
 		}
 
+		// Copy image pull secrets before the first workload of the namespace
+		if utils.Find(secretsNamespaces, ns) == -1 {
+			if err := d.copyPullSecrets(ns); err != nil {
+				return err
+			}
+			secretsNamespaces = append(secretsNamespaces, ns)
+		}
+
 		// Creates service accounts
 		if deployment.k8sServiceAccount.GetObjectMeta().GetName() != "" {
 			fmt.Println("Creating service account ", deployment.k8sServiceAccount.GetObjectMeta().GetName())
@@ -111,6 +172,28 @@ func (d *Deployer) Create() error {
 			fmt.Printf("Created service account %s on namespace %s \n", result.GetObjectMeta().GetName(), result.GetObjectMeta().GetNamespace())
 		}
 
+		// Creates roles
+		if deployment.k8sRole.GetObjectMeta().GetName() != "" {
+			fmt.Println("Creating role ", deployment.k8sRole.GetObjectMeta().GetName())
+			roleClient := d.Client.RbacV1().Roles(ns)
+			result, err := roleClient.Create(deployment.k8sRole)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Created role %s on namespace %s \n", result.GetObjectMeta().GetName(), result.GetObjectMeta().GetNamespace())
+		}
+
+		// Creates role bindings
+		if deployment.k8sRoleBinding.GetObjectMeta().GetName() != "" {
+			fmt.Println("Creating role binding ", deployment.k8sRoleBinding.GetObjectMeta().GetName())
+			roleBindingClient := d.Client.RbacV1().RoleBindings(ns)
+			result, err := roleBindingClient.Create(deployment.k8sRoleBinding)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Created role binding %s on namespace %s \n", result.GetObjectMeta().GetName(), result.GetObjectMeta().GetNamespace())
+		}
+
 		// Creates cluster roles
 		if deployment.k8sClusterRole.GetObjectMeta().GetName() != "" {
 			fmt.Println("Creating cluster role ", deployment.k8sClusterRole.GetObjectMeta().GetName())
@@ -155,14 +238,26 @@ func (d *Deployer) Create() error {
 
 			if resultSvc.Spec.Type == "NodePort" || resultSvc.Spec.Type == "LoadBalancer" {
 				for _, v := range resultSvc.Spec.Ports {
-					deployment.conn = append(deployment.conn, fmt.Sprintf("%s:%d", v.Name, v.NodePort))
+					deployment.conn = append(deployment.conn, fmt.Sprintf("%s %s %s:%d", resultSvc.GetObjectMeta().GetName(), resultSvc.Spec.Type, v.Name, v.NodePort))
 				}
 				// fmt.Println(resultSvc.Spec.LoadBalancerIP)
+			} else if deployment.serviceConversion != "" {
+				deployment.conn = append(deployment.conn, fmt.Sprintf("%s %s (was %s), reachable through connect", resultSvc.GetObjectMeta().GetName(), resultSvc.Spec.Type, apiv1.ServiceTypeLoadBalancer))
 			}
 			fmt.Printf("Created service %s on namespace %s \n", resultSvc.GetObjectMeta().GetName(), resultSvc.GetObjectMeta().GetNamespace())
 		}
 	}
 
+	urls, err := d.createRoutes()
+	if err != nil {
+		return err
+	}
+	hosts, err := d.createIngress()
+	if err != nil {
+		return err
+	}
+	urls = append(urls, hosts...)
+
 	fmt.Println("\nExposed services")
 	for _, deployment := range d.deployments {
 		if len(deployment.conn) > 0 {
@@ -171,6 +266,9 @@ func (d *Deployer) Create() error {
 			}
 		}
 	}
+	for _, v := range urls {
+		fmt.Println(v)
+	}
 
 	return nil
 }
@@ -245,6 +343,46 @@ func (d *Deployer) Delete() error {
 			}
 		}
 
+		nRoleBinding := deployment.k8sRoleBinding.GetObjectMeta().GetName()
+		nsRoleBinding := deployment.k8sRoleBinding.GetObjectMeta().GetNamespace()
+
+		// Deletes role bindings
+		if deployment.k8sRoleBinding.GetObjectMeta().GetName() != "" {
+			fmt.Println("Deleting role binding ", nRoleBinding)
+			roleBindingClient := d.Client.RbacV1().RoleBindings(nsRoleBinding)
+			if err := roleBindingClient.Delete(nRoleBinding, &metav1.DeleteOptions{
+				PropagationPolicy: &deletePolicy,
+			}); err != nil {
+				if strings.Contains(err.Error(), "not found") {
+					fmt.Println(err.Error())
+				} else {
+					return err
+				}
+			} else {
+				fmt.Println("Deleted role binding ", nRoleBinding)
+			}
+		}
+
+		nRole := deployment.k8sRole.GetObjectMeta().GetName()
+		nsRole := deployment.k8sRole.GetObjectMeta().GetNamespace()
+
+		// Deletes roles
+		if deployment.k8sRole.GetObjectMeta().GetName() != "" {
+			fmt.Println("Deleting role ", nRole)
+			roleClient := d.Client.RbacV1().Roles(nsRole)
+			if err := roleClient.Delete(nRole, &metav1.DeleteOptions{
+				PropagationPolicy: &deletePolicy,
+			}); err != nil {
+				if strings.Contains(err.Error(), "not found") {
+					fmt.Println(err.Error())
+				} else {
+					return err
+				}
+			} else {
+				fmt.Println("Deleted role ", nRole)
+			}
+		}
+
 		nClusterRoleBinding := deployment.k8sClusterRoleBinding.GetObjectMeta().GetName()
 
 		// Deletes cluster role bindings
@@ -284,8 +422,20 @@ func (d *Deployer) Delete() error {
 		}
 	}
 
+	if err := d.deleteRoutes(); err != nil {
+		return err
+	}
+
+	if err := d.deleteIngress(); err != nil {
+		return err
+	}
+
 	// Delete deployments's namespaces
 	for _, dns := range deploymentNamespaces {
+		if err := d.deletePullSecrets(dns); err != nil {
+			return err
+		}
+
 		fmt.Println("Deleting namespace ", dns)
 		if err := d.Client.Core().Namespaces().Delete(dns, &metav1.DeleteOptions{}); err != nil {
 			if strings.Contains(err.Error(), "not found") {
@@ -303,7 +453,7 @@ func (d *Deployer) Delete() error {
 
 func (d *Deployer) generateServiceAccounts() error {
 	for _, deployment := range d.deployments {
-		srcYML := fmt.Sprintf("config/%s-svc-account.yml", deployment.component)
+		srcYML := fmt.Sprintf("%s/%s-svc-account.yml", d.Settings.Manifests, deployment.component)
 		f, err := os.Open(srcYML)
 		if err != nil {
 			f, err = os.Open(srcYML)
@@ -313,10 +463,10 @@ func (d *Deployer) generateServiceAccounts() error {
 		}
 
 		if err == nil {
-			if err = yaml.NewYAMLOrJSONDecoder(f, 1000).Decode(deployment.k8sServiceAccount); err != nil {
+			if err = d.decodeManifest(f, deployment.k8sServiceAccount); err != nil {
 				return err
 			}
-			deployment.k8sServiceAccount.Name = deployment.k8sServiceAccount.Name + d.GetNamespace()
+			deployment.k8sServiceAccount.Name = d.derivedName(deployment.k8sServiceAccount.Name)
 			deployment.k8sServiceAccount.Namespace = d.GetNamespace()
 		}
 	}
@@ -326,7 +476,7 @@ func (d *Deployer) generateServiceAccounts() error {
 
 func (d *Deployer) generateClusterRoles() error {
 	for _, deployment := range d.deployments {
-		srcYML := fmt.Sprintf("config/%s-cluster-role.yml", deployment.component)
+		srcYML := fmt.Sprintf("%s/%s-cluster-role.yml", d.Settings.Manifests, deployment.component)
 		f, err := os.Open(srcYML)
 		if err != nil {
 			f, err = os.Open(srcYML)
@@ -336,10 +486,10 @@ func (d *Deployer) generateClusterRoles() error {
 		}
 
 		if err == nil {
-			if err = yaml.NewYAMLOrJSONDecoder(f, 1000).Decode(deployment.k8sClusterRole); err != nil {
+			if err = d.decodeManifest(f, deployment.k8sClusterRole); err != nil {
 				return err
 			}
-			deployment.k8sClusterRole.Name = deployment.k8sClusterRole.Name + d.GetNamespace()
+			deployment.k8sClusterRole.Name = d.derivedName(deployment.k8sClusterRole.Name)
 			deployment.k8sClusterRole.Namespace = d.GetNamespace()
 		}
 	}
@@ -349,7 +499,7 @@ func (d *Deployer) generateClusterRoles() error {
 
 func (d *Deployer) generateClusterRoleBindings() error {
 	for _, deployment := range d.deployments {
-		srcYML := fmt.Sprintf("config/%s-cluster-role-binding.yml", deployment.component)
+		srcYML := fmt.Sprintf("%s/%s-cluster-role-binding.yml", d.Settings.Manifests, deployment.component)
 		f, err := os.Open(srcYML)
 		if err != nil {
 			f, err = os.Open(srcYML)
@@ -359,16 +509,20 @@ func (d *Deployer) generateClusterRoleBindings() error {
 		}
 
 		if err == nil {
-			if err = yaml.NewYAMLOrJSONDecoder(f, 1000).Decode(deployment.k8sClusterRoleBinding); err != nil {
+			if err = d.decodeManifest(f, deployment.k8sClusterRoleBinding); err != nil {
 				return err
 			}
-			deployment.k8sClusterRoleBinding.Name = deployment.k8sClusterRoleBinding.Name + d.GetNamespace()
+			deployment.k8sClusterRoleBinding.Name = d.derivedName(deployment.k8sClusterRoleBinding.Name)
 			deployment.k8sClusterRoleBinding.Namespace = d.GetNamespace()
+			// Point at the cluster role generated for the environment, when it is the one bound
+			if ref := &deployment.k8sClusterRoleBinding.RoleRef; ref.Kind == "ClusterRole" && d.derivedName(ref.Name) == deployment.k8sClusterRole.Name {
+				ref.Name = deployment.k8sClusterRole.Name
+			}
 			for k, v := range deployment.k8sClusterRoleBinding.Subjects {
 				if v.Kind == "ServiceAccount" {
 					if deployment.k8sClusterRoleBinding.Subjects[k].Name != "default" {
 						deployment.k8sClusterRoleBinding.Subjects[k].Name =
-							deployment.k8sClusterRoleBinding.Subjects[k].Name + d.GetNamespace()
+							d.derivedName(deployment.k8sClusterRoleBinding.Subjects[k].Name)
 					}
 					deployment.k8sClusterRoleBinding.Subjects[k].Namespace = d.GetNamespace()
 				}
@@ -379,17 +533,65 @@ func (d *Deployer) generateClusterRoleBindings() error {
 	return nil
 }
 
+func (d *Deployer) generateRoles() error {
+	for _, deployment := range d.deployments {
+		srcYML := fmt.Sprintf("%s/%s-role.yml", d.Settings.Manifests, deployment.component)
+		f, err := os.Open(srcYML)
+		if err != nil {
+			fmt.Println("Role not found for ", srcYML)
+			continue
+		}
+
+		if err = d.decodeManifest(f, deployment.k8sRole); err != nil {
+			return err
+		}
+		deployment.k8sRole.Namespace = d.GetNamespace()
+	}
+
+	return nil
+}
+
+func (d *Deployer) generateRoleBindings() error {
+	for _, deployment := range d.deployments {
+		srcYML := fmt.Sprintf("%s/%s-role-binding.yml", d.Settings.Manifests, deployment.component)
+		f, err := os.Open(srcYML)
+		if err != nil {
+			fmt.Println("Role binding not found for ", srcYML)
+			continue
+		}
+
+		if err = d.decodeManifest(f, deployment.k8sRoleBinding); err != nil {
+			return err
+		}
+		deployment.k8sRoleBinding.Namespace = d.GetNamespace()
+		// Point at the cluster role generated for the environment, when it is the one bound
+		if ref := &deployment.k8sRoleBinding.RoleRef; ref.Kind == "ClusterRole" && d.derivedName(ref.Name) == deployment.k8sClusterRole.Name {
+			ref.Name = deployment.k8sClusterRole.Name
+		}
+		for k, v := range deployment.k8sRoleBinding.Subjects {
+			if v.Kind == "ServiceAccount" {
+				if v.Name != "default" {
+					deployment.k8sRoleBinding.Subjects[k].Name = d.derivedName(v.Name)
+				}
+				deployment.k8sRoleBinding.Subjects[k].Namespace = d.GetNamespace()
+			}
+		}
+	}
+
+	return nil
+}
+
 func (d *Deployer) generateDeployment() error {
 	for _, deployment := range d.deployments {
-		f, err := os.Open(fmt.Sprintf("config/%s.yaml", deployment.component))
+		f, err := os.Open(fmt.Sprintf("%s/%s.yaml", d.Settings.Manifests, deployment.component))
 		if err != nil {
-			f, err = os.Open(fmt.Sprintf("config/%s.yml", deployment.component))
+			f, err = os.Open(fmt.Sprintf("%s/%s.yml", d.Settings.Manifests, deployment.component))
 			if err != nil {
 				return err
 			}
 		}
 
-		if err = yaml.NewYAMLOrJSONDecoder(f, 1000).Decode(deployment.k8sDeployment); err != nil {
+		if err = d.decodeManifest(f, deployment.k8sDeployment); err != nil {
 			return err
 		}
 
@@ -397,9 +599,8 @@ func (d *Deployer) generateDeployment() error {
 		if deployment.k8sDeployment.Spec.Template.Spec.ServiceAccountName != "" {
 			deployment.k8sDeployment.Spec.Template.Spec.ServiceAccountName = deployment.k8sServiceAccount.Name
 		}
-		for k, v := range deployment.k8sDeployment.Spec.Template.Spec.Containers {
-			img := strings.Split(v.Image, ":")
-			deployment.k8sDeployment.Spec.Template.Spec.Containers[k].Image = fmt.Sprintf("%s:%s", img[0], deployment.commitTag)
+		if err := d.rewriteImages(deployment); err != nil {
+			return err
 		}
 	}
 
@@ -408,7 +609,7 @@ func (d *Deployer) generateDeployment() error {
 
 func (d *Deployer) generateServices() error {
 	for _, deployment := range d.deployments {
-		srcYML := fmt.Sprintf("config/%s-svc.yml", deployment.component)
+		srcYML := fmt.Sprintf("%s/%s-svc.yml", d.Settings.Manifests, deployment.component)
 		f, err := os.Open(srcYML)
 		if err != nil {
 			f, err = os.Open(srcYML)
@@ -418,7 +619,7 @@ func (d *Deployer) generateServices() error {
 		}
 
 		if err == nil {
-			if err = yaml.NewYAMLOrJSONDecoder(f, 1000).Decode(deployment.k8sService); err != nil {
+			if err = d.decodeManifest(f, deployment.k8sService); err != nil {
 				return err
 			}
 
@@ -429,8 +630,9 @@ func (d *Deployer) generateServices() error {
 	return nil
 }
 
+// SetNamespace sets the environment namespace, turned into a valid namespace name
 func (d *Deployer) SetNamespace(ns string) {
-	d.namespace = strings.Replace(ns, ".", "-", -1)
+	d.namespace = dnsLabel(ns)
 }
 
 func (d *Deployer) GetNamespace() string {
@@ -456,11 +658,17 @@ type deployment struct {
 	repo                  string
 	commitTag             string
 	conn                  []string
+	rewrites              []string
+	pins                  map[string]string
+	serviceConversion     string
+	rbacDowngrade         string
 	k8sDeployment         *appsv1.Deployment
 	k8sService            *apiv1.Service
 	k8sServiceAccount     *apiv1.ServiceAccount
 	k8sClusterRole        *rbacv1.ClusterRole
 	k8sClusterRoleBinding *rbacv1.ClusterRoleBinding
+	k8sRole               *rbacv1.Role
+	k8sRoleBinding        *rbacv1.RoleBinding
 }
 
 func newDeployment(c string, r string, ct string) *deployment {
@@ -474,5 +682,7 @@ func newDeployment(c string, r string, ct string) *deployment {
 		k8sServiceAccount:     &apiv1.ServiceAccount{},
 		k8sClusterRole:        &rbacv1.ClusterRole{},
 		k8sClusterRoleBinding: &rbacv1.ClusterRoleBinding{},
+		k8sRole:               &rbacv1.Role{},
+		k8sRoleBinding:        &rbacv1.RoleBinding{},
 	}
 }
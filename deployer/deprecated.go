@@ -0,0 +1,89 @@
+package deployer
+
+import (
+	"fmt"
+
+	"github.com/Rakanixu/k8-cid/utils"
+)
+
+const (
+	rbacV1       = "rbac.authorization.k8s.io/v1"
+	appsV1       = "apps/v1"
+	networkingV1 = "networking.k8s.io/v1"
+)
+
+var (
+	rbacKinds     = []string{"Role", "ClusterRole", "RoleBinding", "ClusterRoleBinding"}
+	workloadKinds = []string{"Deployment", "DaemonSet", "ReplicaSet", "StatefulSet"}
+)
+
+// translateDocument converts a manifest document of a deprecated group/version to
+// the supported one, warning about it. It returns whether the document changed.
+func translateDocument(path string, index int, obj map[string]interface{}) bool {
+	from, _ := obj["apiVersion"].(string)
+	kind, _ := obj["kind"].(string)
+
+	to := translateAPIVersion(obj)
+	if to == "" {
+		return false
+	}
+	fmt.Printf("Warning: %s: document %d: %s %s is deprecated, translated to %s\n", path, index, kind, from, to)
+
+	return true
+}
+
+// translateAPIVersion converts an object of a deprecated group/version in place,
+// returning the apiVersion it was converted to, or an empty string if it is not deprecated:
+//
+//	rbac.authorization.k8s.io/v1alpha1 and v1beta1 roles and bindings to rbac.authorization.k8s.io/v1
+//	extensions/v1beta1, apps/v1beta1 and apps/v1beta2 workloads to apps/v1, adding the selector
+//	extensions/v1beta1 network policies to networking.k8s.io/v1
+//
+// Ingresses are kept on extensions/v1beta1, the version the ingresses of the
+// environments are created with.
+func translateAPIVersion(obj map[string]interface{}) string {
+	apiVersion, _ := obj["apiVersion"].(string)
+	kind, _ := obj["kind"].(string)
+
+	var to string
+	switch apiVersion {
+	case "rbac.authorization.k8s.io/v1alpha1", "rbac.authorization.k8s.io/v1beta1":
+		if utils.Find(rbacKinds, kind) != -1 {
+			to = rbacV1
+		}
+	case "extensions/v1beta1", "apps/v1beta1", "apps/v1beta2":
+		if utils.Find(workloadKinds, kind) != -1 {
+			to = appsV1
+			translateWorkload(obj)
+		}
+	}
+	if kind == "NetworkPolicy" && apiVersion == "extensions/v1beta1" {
+		to = networkingV1
+	}
+
+	if to != "" {
+		obj["apiVersion"] = to
+	}
+
+	return to
+}
+
+// translateWorkload selects the pod template labels, the default before apps/v1,
+// and drops the fields apps/v1 removed
+func translateWorkload(obj map[string]interface{}) {
+	spec, ok := obj["spec"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	delete(spec, "rollbackTo")
+	delete(spec, "templateGeneration")
+
+	if _, ok := spec["selector"]; ok {
+		return
+	}
+	template, _ := spec["template"].(map[string]interface{})
+	meta, _ := template["metadata"].(map[string]interface{})
+	if labels, ok := meta["labels"].(map[string]interface{}); ok && len(labels) > 0 {
+		spec["selector"] = map[string]interface{}{"matchLabels": labels}
+	}
+}
@@ -0,0 +1,78 @@
+package deployer
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestTranslateAPIVersion(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		to   string
+		out  string
+	}{
+		{
+			name: "role",
+			in:   `{"apiVersion": "rbac.authorization.k8s.io/v1beta1", "kind": "Role"}`,
+			to:   rbacV1,
+			out:  `{"apiVersion": "rbac.authorization.k8s.io/v1", "kind": "Role"}`,
+		},
+		{
+			name: "deployment without selector",
+			in: `{"apiVersion": "extensions/v1beta1", "kind": "Deployment", "spec": {"rollbackTo": {"revision": 1},
+				"templateGeneration": 2, "template": {"metadata": {"labels": {"app": "mercury"}}}}}`,
+			to: appsV1,
+			out: `{"apiVersion": "apps/v1", "kind": "Deployment", "spec": {"selector": {"matchLabels": {"app": "mercury"}},
+				"template": {"metadata": {"labels": {"app": "mercury"}}}}}`,
+		},
+		{
+			name: "stateful set with selector",
+			in: `{"apiVersion": "apps/v1beta2", "kind": "StatefulSet", "spec": {"selector": {"matchLabels": {"app": "db"}},
+				"template": {"metadata": {"labels": {"app": "db", "tier": "data"}}}}}`,
+			to: appsV1,
+			out: `{"apiVersion": "apps/v1", "kind": "StatefulSet", "spec": {"selector": {"matchLabels": {"app": "db"}},
+				"template": {"metadata": {"labels": {"app": "db", "tier": "data"}}}}}`,
+		},
+		{
+			name: "ingress",
+			in:   `{"apiVersion": "extensions/v1beta1", "kind": "Ingress", "spec": {"backend": {"serviceName": "web", "servicePort": 80}}}`,
+			out:  `{"apiVersion": "extensions/v1beta1", "kind": "Ingress", "spec": {"backend": {"serviceName": "web", "servicePort": 80}}}`,
+		},
+		{
+			name: "network policy",
+			in:   `{"apiVersion": "extensions/v1beta1", "kind": "NetworkPolicy"}`,
+			to:   networkingV1,
+			out:  `{"apiVersion": "networking.k8s.io/v1", "kind": "NetworkPolicy"}`,
+		},
+		{
+			name: "supported version",
+			in:   `{"apiVersion": "apps/v1", "kind": "Deployment", "spec": {"templateGeneration": 2}}`,
+			out:  `{"apiVersion": "apps/v1", "kind": "Deployment", "spec": {"templateGeneration": 2}}`,
+		},
+		{
+			name: "deprecated group of another kind",
+			in:   `{"apiVersion": "extensions/v1beta1", "kind": "PodSecurityPolicy"}`,
+			out:  `{"apiVersion": "extensions/v1beta1", "kind": "PodSecurityPolicy"}`,
+		},
+	}
+
+	for _, test := range tests {
+		obj := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(test.in), &obj); err != nil {
+			t.Fatal(err)
+		}
+		want := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(test.out), &want); err != nil {
+			t.Fatal(err)
+		}
+
+		if to := translateAPIVersion(obj); to != test.to {
+			t.Errorf("%s: translated to %q, want %q", test.name, to, test.to)
+		}
+		got, _ := json.Marshal(obj)
+		if w, _ := json.Marshal(want); string(got) != string(w) {
+			t.Errorf("%s: got %s\nwant %s", test.name, got, w)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package deployer
+
+import (
+	"fmt"
+	"strings"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// podProblem explains why a pod of a component is not getting ready. Fatal problems
+// will not go away by waiting longer.
+type podProblem struct {
+	component string
+	pod       string
+	reason    string
+	fatal     bool
+}
+
+func (p podProblem) String() string {
+	return fmt.Sprintf("%s: pod %s %s", p.component, p.pod, p.reason)
+}
+
+// diagnose inspects the pods and events of a component looking for known failures:
+// images that cannot be pulled, crash loops, OOM kills and unschedulable pods.
+func (d *Deployer) diagnose(deployment *deployment, events []apiv1.Event) ([]podProblem, error) {
+	var problems []podProblem
+
+	selector, err := metav1.LabelSelectorAsSelector(deployment.k8sDeployment.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := d.Client.CoreV1().Pods(d.GetNamespace()).List(metav1.ListOptions{
+		LabelSelector: selector.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for k := range pods.Items {
+		pod := &pods.Items[k]
+		newProblem := func(fatal bool, format string, a ...interface{}) {
+			problems = append(problems, podProblem{
+				component: deployment.component,
+				pod:       pod.GetObjectMeta().GetName(),
+				reason:    fmt.Sprintf(format, a...),
+				fatal:     fatal,
+			})
+		}
+
+		statuses := append([]apiv1.ContainerStatus{}, pod.Status.InitContainerStatuses...)
+		statuses = append(statuses, pod.Status.ContainerStatuses...)
+		for _, cs := range statuses {
+			if w := cs.State.Waiting; w != nil {
+				switch w.Reason {
+				case "ErrImagePull", "ImagePullBackOff", "InvalidImageName":
+					newProblem(true, "cannot pull image %s for container %s (%s): %s",
+						cs.Image, cs.Name, w.Reason, orEventMessage(w.Message, pod, events))
+				case "CreateContainerConfigError":
+					newProblem(true, "cannot create container %s: %s", cs.Name, w.Message)
+				case "CrashLoopBackOff":
+					if oomKilled(cs.LastTerminationState) {
+						newProblem(true, "container %s is crash looping after %d restarts, OOM killed, memory limit %s",
+							cs.Name, cs.RestartCount, memoryLimit(pod, cs.Name))
+					} else {
+						newProblem(true, "container %s is crash looping after %d restarts, last run %s",
+							cs.Name, cs.RestartCount, describeContainerState(cs.LastTerminationState))
+					}
+				}
+			}
+
+			// A container OOM killed once may run fine after restarting, it is only
+			// fatal while it stays down
+			if oomKilled(cs.State) {
+				newProblem(true, "container %s was OOM killed, memory limit %s",
+					cs.Name, memoryLimit(pod, cs.Name))
+			} else if oomKilled(cs.LastTerminationState) && (cs.State.Waiting == nil || cs.State.Waiting.Reason != "CrashLoopBackOff") {
+				newProblem(false, "container %s was OOM killed and restarted, memory limit %s",
+					cs.Name, memoryLimit(pod, cs.Name))
+			}
+		}
+
+		for _, c := range pod.Status.Conditions {
+			if c.Type != apiv1.PodScheduled || c.Status != apiv1.ConditionFalse || c.Reason != apiv1.PodReasonUnschedulable {
+				continue
+			}
+
+			// A node selector no node matches will never be scheduled, anything else
+			// (e.g. lack of resources) may be fixed by the cluster autoscaler
+			matches, err := d.nodesMatching(pod.Spec.NodeSelector)
+			if err != nil {
+				return nil, err
+			}
+			if len(pod.Spec.NodeSelector) > 0 && matches == 0 {
+				newProblem(true, "cannot be scheduled, no node matches nodeSelector %s",
+					labels.SelectorFromSet(pod.Spec.NodeSelector).String())
+			} else {
+				newProblem(false, "cannot be scheduled: %s", orEventMessage(c.Message, pod, events))
+			}
+		}
+	}
+
+	return problems, nil
+}
+
+func oomKilled(s apiv1.ContainerState) bool {
+	return s.Terminated != nil && s.Terminated.Reason == "OOMKilled"
+}
+
+// nodesMatching returns how many nodes have all the given labels
+func (d *Deployer) nodesMatching(nodeSelector map[string]string) (int, error) {
+	nodes, err := d.Client.CoreV1().Nodes().List(metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(nodeSelector).String(),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(nodes.Items), nil
+}
+
+// orEventMessage returns msg, or the message of the latest warning event of the pod when empty
+func orEventMessage(msg string, pod *apiv1.Pod, events []apiv1.Event) string {
+	if msg != "" {
+		return msg
+	}
+
+	var latest *apiv1.Event
+	for k, e := range events {
+		if e.Type != apiv1.EventTypeWarning || e.InvolvedObject.Kind != "Pod" || e.InvolvedObject.Name != pod.GetObjectMeta().GetName() {
+			continue
+		}
+		if latest == nil || latest.LastTimestamp.Before(&events[k].LastTimestamp) {
+			latest = &events[k]
+		}
+	}
+	if latest == nil {
+		return "no details available"
+	}
+
+	return strings.TrimSpace(latest.Message)
+}
+
+func memoryLimit(pod *apiv1.Pod, container string) string {
+	for _, c := range pod.Spec.Containers {
+		if c.Name != container {
+			continue
+		}
+		if l, ok := c.Resources.Limits[apiv1.ResourceMemory]; ok {
+			return l.String()
+		}
+	}
+
+	return "not set"
+}
@@ -0,0 +1,166 @@
+package deployer
+
+import (
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testPod(name string, app string, nodeSelector map[string]string, status apiv1.PodStatus) *apiv1.Pod {
+	return &apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "env", Labels: map[string]string{"app": app}},
+		Spec: apiv1.PodSpec{
+			NodeSelector: nodeSelector,
+			Containers: []apiv1.Container{{
+				Name: "main",
+				Resources: apiv1.ResourceRequirements{
+					Limits: apiv1.ResourceList{apiv1.ResourceMemory: resource.MustParse("64Mi")},
+				},
+			}},
+		},
+		Status: status,
+	}
+}
+
+func containerStatus(state apiv1.ContainerState, last apiv1.ContainerState) apiv1.PodStatus {
+	return apiv1.PodStatus{ContainerStatuses: []apiv1.ContainerStatus{{
+		Name:                 "main",
+		Image:                "us.gcr.io/p/mercury:089eb18d",
+		State:                state,
+		LastTerminationState: last,
+		RestartCount:         3,
+	}}}
+}
+
+func waiting(reason string) apiv1.ContainerState {
+	return apiv1.ContainerState{Waiting: &apiv1.ContainerStateWaiting{Reason: reason}}
+}
+
+func terminated(reason string) apiv1.ContainerState {
+	return apiv1.ContainerState{Terminated: &apiv1.ContainerStateTerminated{Reason: reason, ExitCode: 137}}
+}
+
+func unschedulable(message string) apiv1.PodStatus {
+	return apiv1.PodStatus{Conditions: []apiv1.PodCondition{{
+		Type:    apiv1.PodScheduled,
+		Status:  apiv1.ConditionFalse,
+		Reason:  apiv1.PodReasonUnschedulable,
+		Message: message,
+	}}}
+}
+
+func TestDiagnose(t *testing.T) {
+	running := apiv1.ContainerState{Running: &apiv1.ContainerStateRunning{}}
+	pool := map[string]string{"cloud.google.com/gke-nodepool": "highmem"}
+
+	tests := []struct {
+		name    string
+		pod     *apiv1.Pod
+		fatal   bool
+		reason  string
+		healthy bool
+	}{
+		{
+			name:    "running",
+			pod:     testPod("running", "mercury", nil, containerStatus(running, apiv1.ContainerState{})),
+			healthy: true,
+		},
+		{
+			name:   "image pull",
+			pod:    testPod("pull", "mercury", nil, containerStatus(waiting("ImagePullBackOff"), apiv1.ContainerState{})),
+			fatal:  true,
+			reason: "cannot pull image us.gcr.io/p/mercury:089eb18d for container main (ImagePullBackOff): Back-off pulling image",
+		},
+		{
+			name:   "crash loop",
+			pod:    testPod("crash", "mercury", nil, containerStatus(waiting("CrashLoopBackOff"), terminated("Error"))),
+			fatal:  true,
+			reason: "container main is crash looping after 3 restarts",
+		},
+		{
+			name:   "crash loop OOM killed",
+			pod:    testPod("crash-oom", "mercury", nil, containerStatus(waiting("CrashLoopBackOff"), terminated("OOMKilled"))),
+			fatal:  true,
+			reason: "container main is crash looping after 3 restarts, OOM killed, memory limit 64Mi",
+		},
+		{
+			name:   "OOM killed",
+			pod:    testPod("oom", "mercury", nil, containerStatus(terminated("OOMKilled"), apiv1.ContainerState{})),
+			fatal:  true,
+			reason: "container main was OOM killed, memory limit 64Mi",
+		},
+		{
+			name:   "OOM killed and restarted",
+			pod:    testPod("restarted", "mercury", nil, containerStatus(running, terminated("OOMKilled"))),
+			reason: "container main was OOM killed and restarted, memory limit 64Mi",
+		},
+		{
+			name:   "no node matches",
+			pod:    testPod("nodepool", "mercury", pool, unschedulable("0/3 nodes are available")),
+			fatal:  true,
+			reason: "cannot be scheduled, no node matches nodeSelector cloud.google.com/gke-nodepool=highmem",
+		},
+		{
+			name:   "lack of resources",
+			pod:    testPod("resources", "mercury", nil, unschedulable("0/3 nodes are available: 3 Insufficient cpu.")),
+			reason: "cannot be scheduled: 0/3 nodes are available: 3 Insufficient cpu.",
+		},
+		{
+			name:    "other component",
+			pod:     testPod("other", "venus", nil, containerStatus(waiting("ImagePullBackOff"), apiv1.ContainerState{})),
+			healthy: true,
+		},
+	}
+
+	events := []apiv1.Event{{
+		Type:           apiv1.EventTypeWarning,
+		InvolvedObject: apiv1.ObjectReference{Kind: "Pod", Name: "pull"},
+		Message:        "Back-off pulling image",
+	}}
+
+	for _, test := range tests {
+		cluster := newTestCluster()
+		cluster.add(t, "/api/v1/namespaces/env/pods/"+test.pod.Name, test.pod)
+		cluster.add(t, "/api/v1/nodes/default-pool", &apiv1.Node{ObjectMeta: metav1.ObjectMeta{
+			Name:   "default-pool",
+			Labels: map[string]string{"cloud.google.com/gke-nodepool": "default-pool"},
+		}})
+		d, done := newTestDeployer(t, cluster)
+
+		deployment := newDeployment("mercury", "juno", "089eb18d")
+		deployment.k8sDeployment = &appsv1.Deployment{Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "mercury"}},
+		}}
+		problems, err := d.diagnose(deployment, events)
+		done()
+		if err != nil {
+			t.Errorf("%s: %s", test.name, err)
+			continue
+		}
+
+		if test.healthy {
+			if len(problems) > 0 {
+				t.Errorf("%s: got problems %v", test.name, problems)
+			}
+			continue
+		}
+		if len(problems) != 1 {
+			t.Errorf("%s: got problems %v, want one", test.name, problems)
+			continue
+		}
+		p := problems[0]
+		if p.component != "mercury" || p.pod != test.pod.Name {
+			t.Errorf("%s: got problem of %s/%s", test.name, p.component, p.pod)
+		}
+		if p.fatal != test.fatal {
+			t.Errorf("%s: got fatal %t, want %t", test.name, p.fatal, test.fatal)
+		}
+		if !strings.HasPrefix(p.reason, test.reason) {
+			t.Errorf("%s: got reason %q, want %q", test.name, p.reason, test.reason)
+		}
+	}
+}
@@ -0,0 +1,313 @@
+package deployer
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Rakanixu/k8-cid/utils"
+	yaml "gopkg.in/yaml.v2"
+
+	apiv1 "k8s.io/api/core/v1"
+	extv1beta1 "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	envTemplate      = "{env}"
+	environmentLabel = "k8-cid/environment"
+	routesName       = "k8-cid-routes"
+	ingressClassKey  = "kubernetes.io/ingress.class"
+	nginxRewriteKey  = "nginx.ingress.kubernetes.io/rewrite-target"
+	nginxRegexKey    = "nginx.ingress.kubernetes.io/use-regex"
+	// defaultRewrite is what Ambassador rewrites mapping prefixes to when they do not say
+	defaultRewrite = "/"
+)
+
+// route sends the requests of the shared gateway matching a host and path prefix to a
+// service, with the prefix rewritten
+type route struct {
+	name    string
+	service string
+	port    int32
+	host    string
+	path    string
+	rewrite string
+}
+
+// mappingPrefix is the prefix of an Ambassador mapping and what it is rewritten to
+type mappingPrefix struct {
+	prefix  string
+	rewrite string
+}
+
+// generateRoutes builds the shared gateway routes to the services of the environment.
+// Services declaring Ambassador mappings get a route per mapping prefix, nested under
+// the environment prefix, the rest get one to /<service>/. The Ambassador annotations
+// of the services are dropped, the shared gateway would otherwise serve the same
+// prefixes for every environment.
+func (d *Deployer) generateRoutes() error {
+	g := d.Settings.Gateway
+	if !g.Shared {
+		return nil
+	}
+	if g.Prefix == "" && g.Host == "" {
+		return fmt.Errorf("Shared gateway needs a prefix or a host to route environments")
+	}
+
+	prefix := "/" + strings.Trim(d.gatewayTemplate(g.Prefix), "/") + "/"
+	prefix = strings.Replace(prefix, "//", "/", -1)
+	host := d.gatewayTemplate(g.Host)
+
+	for _, deployment := range d.deployments {
+		svc := deployment.k8sService
+		if svc.GetObjectMeta().GetName() == "" || len(svc.Spec.Ports) == 0 {
+			continue
+		}
+
+		paths, err := servicePrefixes(svc)
+		if err != nil {
+			return fmt.Errorf("%s: invalid %s annotation: %s", deployment.component, ambassadorConfigAnnotation, err)
+		}
+		for k, p := range paths {
+			d.routes = append(d.routes, route{
+				name:    strings.Replace(fmt.Sprintf("%s_%s_%d", d.GetNamespace(), svc.GetObjectMeta().GetName(), k), "-", "_", -1),
+				service: svc.GetObjectMeta().GetName(),
+				port:    svc.Spec.Ports[0].Port,
+				host:    host,
+				path:    prefix + strings.TrimPrefix(p.prefix, "/"),
+				rewrite: p.rewrite,
+			})
+		}
+
+		if _, ok := svc.Annotations[ambassadorConfigAnnotation]; ok {
+			delete(svc.Annotations, ambassadorConfigAnnotation)
+			fmt.Printf("Moved the Ambassador mappings of service %s to the shared gateway\n", svc.GetObjectMeta().GetName())
+		}
+	}
+
+	switch g.Kind {
+	case utils.GatewayMapping, "":
+		if g.Namespace == "" {
+			return fmt.Errorf("Shared gateway mappings need the gateway namespace")
+		}
+		d.routesService = d.mappingService()
+		return nil
+	case utils.GatewayIngress:
+		var err error
+		d.routesIngress, err = d.routesIngressSpec()
+		return err
+	}
+
+	return fmt.Errorf("Unknown shared gateway kind %s", g.Kind)
+}
+
+func (d *Deployer) gatewayTemplate(s string) string {
+	return strings.Replace(s, envTemplate, d.GetNamespace(), -1)
+}
+
+// servicePrefixes returns the prefixes of the Ambassador mappings of a service, or /<service>/
+func servicePrefixes(svc *apiv1.Service) ([]mappingPrefix, error) {
+	var prefixes []mappingPrefix
+
+	config := svc.GetObjectMeta().GetAnnotations()[ambassadorConfigAnnotation]
+	for _, doc := range splitYAMLDocuments(config) {
+		m := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(doc), &m); err != nil {
+			return nil, err
+		}
+		if m["kind"] != "Mapping" {
+			continue
+		}
+		if p, ok := m["prefix"].(string); ok && p != "" {
+			rewrite, ok := m["rewrite"].(string)
+			if !ok {
+				rewrite = defaultRewrite
+			}
+			prefixes = append(prefixes, mappingPrefix{prefix: p, rewrite: rewrite})
+		}
+	}
+
+	if len(prefixes) == 0 {
+		prefixes = append(prefixes, mappingPrefix{prefix: "/" + svc.GetObjectMeta().GetName() + "/", rewrite: defaultRewrite})
+	}
+
+	return prefixes, nil
+}
+
+func (d *Deployer) routesMeta(name string, namespace string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      name,
+		Namespace: namespace,
+		Labels: map[string]string{
+			managedByLabel:   managedByValue,
+			environmentLabel: d.GetNamespace(),
+		},
+		Annotations: map[string]string{},
+	}
+}
+
+// mappingService returns a service of the gateway namespace carrying the Ambassador
+// mappings of the environment, pointing at the services of its namespace
+func (d *Deployer) mappingService() *apiv1.Service {
+	var docs []string
+	for _, r := range d.routes {
+		m := yaml.MapSlice{
+			{Key: "apiVersion", Value: "ambassador/v0"},
+			{Key: "kind", Value: "Mapping"},
+			{Key: "name", Value: r.name},
+			{Key: "prefix", Value: r.path},
+			{Key: "rewrite", Value: r.rewrite},
+			{Key: "service", Value: fmt.Sprintf("%s.%s:%d", r.service, d.GetNamespace(), r.port)},
+		}
+		if r.host != "" {
+			m = append(m, yaml.MapItem{Key: "host", Value: r.host})
+		}
+		if d.Settings.Gateway.AmbassadorID != "" {
+			m = append(m, yaml.MapItem{Key: ambassadorIDKey, Value: d.Settings.Gateway.AmbassadorID})
+		}
+
+		b, _ := yaml.Marshal(m)
+		docs = append(docs, "---\n"+string(b))
+	}
+
+	svc := &apiv1.Service{
+		ObjectMeta: d.routesMeta(d.derivedName("routes-"), d.Settings.Gateway.Namespace),
+		Spec: apiv1.ServiceSpec{
+			Type: apiv1.ServiceTypeClusterIP,
+			Ports: []apiv1.ServicePort{
+				{Name: "http", Port: 80},
+			},
+		},
+	}
+	svc.Annotations[ambassadorConfigAnnotation] = strings.Join(docs, "")
+
+	return svc
+}
+
+// routesIngressSpec returns an ingress of the environment namespace routing to its
+// services. Route prefixes are rewritten to / with the annotations of the NGINX ingress
+// controller, unless rewrites are turned off, as one ingress only takes one rewrite.
+func (d *Deployer) routesIngressSpec() (*extv1beta1.Ingress, error) {
+	ingress := &extv1beta1.Ingress{
+		ObjectMeta: d.routesMeta(routesName, d.GetNamespace()),
+	}
+	if d.Settings.Gateway.IngressClass != "" {
+		ingress.Annotations[ingressClassKey] = d.Settings.Gateway.IngressClass
+	}
+
+	rewrite := false
+	switch d.Settings.Gateway.IngressRewrite {
+	case utils.IngressRewriteNginx, "":
+		rewrite = true
+		ingress.Annotations[nginxRegexKey] = "true"
+		ingress.Annotations[nginxRewriteKey] = "/$2"
+	case utils.IngressRewriteNone:
+	default:
+		return nil, fmt.Errorf("Unknown shared gateway ingress rewrite %s", d.Settings.Gateway.IngressRewrite)
+	}
+
+	rule := extv1beta1.IngressRule{
+		IngressRuleValue: extv1beta1.IngressRuleValue{
+			HTTP: &extv1beta1.HTTPIngressRuleValue{},
+		},
+	}
+	for _, r := range d.routes {
+		path := r.path
+		if rewrite {
+			if r.rewrite != defaultRewrite {
+				fmt.Printf("Route %s rewrites to %s on the shared ingress, not to %s\n", r.path, defaultRewrite, r.rewrite)
+			}
+			// The second group is what is left after the prefix, e.g. /<env>/mercury/users
+			// reaches mercury as /users
+			path = regexp.QuoteMeta(strings.TrimSuffix(r.path, "/")) + "(/|$)(.*)"
+		}
+
+		rule.Host = r.host
+		rule.HTTP.Paths = append(rule.HTTP.Paths, extv1beta1.HTTPIngressPath{
+			Path: path,
+			Backend: extv1beta1.IngressBackend{
+				ServiceName: r.service,
+				ServicePort: intstr.FromInt(int(r.port)),
+			},
+		})
+	}
+	ingress.Spec.Rules = append(ingress.Spec.Rules, rule)
+
+	return ingress, nil
+}
+
+// createRoutes creates the routing objects of the environment on the shared gateway
+// and returns its endpoints
+func (d *Deployer) createRoutes() ([]string, error) {
+	if d.routesService != nil {
+		fmt.Println("Creating gateway mappings ", d.routesService.GetObjectMeta().GetName())
+		result, err := d.Client.CoreV1().Services(d.routesService.GetObjectMeta().GetNamespace()).Create(d.routesService)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Printf("Created gateway mappings %s on namespace %s \n", result.GetObjectMeta().GetName(), result.GetObjectMeta().GetNamespace())
+	}
+
+	if d.routesIngress != nil {
+		fmt.Println("Creating ingress ", d.routesIngress.GetObjectMeta().GetName())
+		result, err := d.Client.ExtensionsV1beta1().Ingresses(d.GetNamespace()).Create(d.routesIngress)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Printf("Created ingress %s on namespace %s \n", result.GetObjectMeta().GetName(), result.GetObjectMeta().GetNamespace())
+	}
+
+	return d.routeURLs(), nil
+}
+
+// routeURLs returns the URL of every route of the environment
+func (d *Deployer) routeURLs() []string {
+	var urls []string
+
+	base := d.gatewayTemplate(d.Settings.Gateway.URL)
+	for _, r := range d.routes {
+		b := base
+		if b == "" && r.host != "" {
+			b = "http://" + r.host
+		}
+		urls = append(urls, fmt.Sprintf("%s %s%s", r.service, strings.TrimRight(b, "/"), r.path))
+	}
+
+	return urls
+}
+
+// deleteRoutes deletes the routing objects of the environment from the shared gateway
+func (d *Deployer) deleteRoutes() error {
+	if d.routesService != nil {
+		n := d.routesService.GetObjectMeta().GetName()
+		fmt.Println("Deleting gateway mappings ", n)
+		if err := d.Client.CoreV1().Services(d.routesService.GetObjectMeta().GetNamespace()).Delete(n, &metav1.DeleteOptions{}); err != nil {
+			if errors.IsNotFound(err) {
+				fmt.Println(err.Error())
+			} else {
+				return err
+			}
+		} else {
+			fmt.Println("Deleted gateway mappings ", n)
+		}
+	}
+
+	if d.routesIngress != nil {
+		n := d.routesIngress.GetObjectMeta().GetName()
+		fmt.Println("Deleting ingress ", n)
+		if err := d.Client.ExtensionsV1beta1().Ingresses(d.GetNamespace()).Delete(n, &metav1.DeleteOptions{}); err != nil {
+			if errors.IsNotFound(err) {
+				fmt.Println(err.Error())
+			} else {
+				return err
+			}
+		} else {
+			fmt.Println("Deleted ingress ", n)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package deployer
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/Rakanixu/k8-cid/image"
+	"github.com/Rakanixu/k8-cid/utils"
+
+	apiv1 "k8s.io/api/core/v1"
+)
+
+// rewriteImages points the containers and init containers of a component at the
+// commit tag of its repo, as decided by the image tag rules, and then applies the
+// image rewrite rules
+func (d *Deployer) rewriteImages(deployment *deployment) error {
+	spec := &deployment.k8sDeployment.Spec.Template.Spec
+
+	for _, containers := range [][]apiv1.Container{spec.InitContainers, spec.Containers} {
+		for k, v := range containers {
+			ref, err := image.Parse(v.Image)
+			if err != nil {
+				return fmt.Errorf("%s: %s", deployment.component, err)
+			}
+
+			if d.retag(deployment, v.Name, ref) {
+				ref = ref.WithTag(deployment.commitTag)
+			} else {
+				fmt.Printf("Keeping image %s of container %s\n", v.Image, v.Name)
+			}
+
+			tagged := ref.String()
+			if ref, err = d.rewrite(deployment, ref); err != nil {
+				return fmt.Errorf("%s: %s", deployment.component, err)
+			}
+			if ref.String() != tagged {
+				rewrite := fmt.Sprintf("%s %s -> %s", v.Name, tagged, ref.String())
+				fmt.Println("Rewriting image of container ", rewrite)
+				deployment.rewrites = append(deployment.rewrites, rewrite)
+			}
+
+			containers[k].Image = ref.String()
+		}
+	}
+
+	return nil
+}
+
+func (d *Deployer) retag(deployment *deployment, container string, ref image.Reference) bool {
+	for _, r := range d.Settings.Images.TagRules {
+		if ruleMatches(r, deployment, container, ref) {
+			return r.Retag
+		}
+	}
+
+	return ref.Digest == ""
+}
+
+func ruleMatches(r utils.TagRule, deployment *deployment, container string, ref image.Reference) bool {
+	if r.Repo != "" && r.Repo != deployment.repo {
+		return false
+	}
+	if r.Component != "" && r.Component != deployment.component {
+		return false
+	}
+	if r.Container != "" {
+		if ok, _ := path.Match(r.Container, container); !ok {
+			return false
+		}
+	}
+	if r.Image != "" {
+		if ok, _ := path.Match(r.Image, ref.Name()); !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (d *Deployer) rewrite(deployment *deployment, ref image.Reference) (image.Reference, error) {
+	for _, r := range d.Settings.Images.RewriteRules {
+		if r.Repo != "" && r.Repo != deployment.repo {
+			continue
+		}
+		if r.Component != "" && r.Component != deployment.component {
+			continue
+		}
+
+		switch r.Type {
+		case utils.RewritePrefix:
+			// The prefix has to end on a path boundary, us.gcr.io/proj is not a
+			// prefix of us.gcr.io/proj-other/mercury
+			from := strings.TrimSuffix(r.From, "/")
+			if ref.Name() != from && !strings.HasPrefix(ref.Name(), from+"/") {
+				continue
+			}
+			rewritten, err := image.Parse(strings.TrimSuffix(r.To, "/") + strings.TrimPrefix(ref.Name(), from))
+			if err != nil {
+				return ref, err
+			}
+			rewritten.Tag = ref.Tag
+			rewritten.Digest = ref.Digest
+			ref = rewritten
+		case utils.RewriteRegistry:
+			if ref.Registry() == r.From {
+				// Official Docker Hub images keep their implicit library/ on the mirror
+				ref.Path = ref.Repository()
+				ref.Domain = r.To
+			}
+		case utils.RewriteRepository:
+			if ref.Path == r.From {
+				ref.Path = r.To
+			}
+		default:
+			return ref, fmt.Errorf("Unknown image rewrite rule type %s", r.Type)
+		}
+	}
+
+	return ref, nil
+}
@@ -0,0 +1,216 @@
+package deployer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Rakanixu/k8-cid/utils"
+
+	extv1beta1 "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// ingressAnnotation on a service manifest exposes it on the environment ingress.
+	// Its value is the host template, e.g. mercury.{env}.{domain}, or true for
+	// <service>.{env}.{domain}.
+	ingressAnnotation = "k8-cid/ingress"
+	domainTemplate    = "{domain}"
+	ingressName       = "k8-cid-ingress"
+	tlsSecretName     = "k8-cid-tls"
+)
+
+// generateIngress builds the ingress of the environment, with a rule per annotated service
+func (d *Deployer) generateIngress() error {
+	var rules []extv1beta1.IngressRule
+	var hosts []string
+
+	for _, deployment := range d.deployments {
+		svc := deployment.k8sService
+		host, ok := svc.GetObjectMeta().GetAnnotations()[ingressAnnotation]
+		if !ok || svc.GetObjectMeta().GetName() == "" {
+			continue
+		}
+		if len(svc.Spec.Ports) == 0 {
+			return fmt.Errorf("%s: service %s has no port to expose on the ingress", deployment.component, svc.GetObjectMeta().GetName())
+		}
+
+		host, err := d.ingressHost(host, svc.GetObjectMeta().GetName())
+		if err != nil {
+			return fmt.Errorf("%s: %s", deployment.component, err)
+		}
+		hosts = append(hosts, host)
+
+		rules = append(rules, extv1beta1.IngressRule{
+			Host: host,
+			IngressRuleValue: extv1beta1.IngressRuleValue{
+				HTTP: &extv1beta1.HTTPIngressRuleValue{
+					Paths: []extv1beta1.HTTPIngressPath{
+						{
+							Path: "/",
+							Backend: extv1beta1.IngressBackend{
+								ServiceName: svc.GetObjectMeta().GetName(),
+								ServicePort: intstr.FromInt(int(svc.Spec.Ports[0].Port)),
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+
+	if len(rules) == 0 {
+		return nil
+	}
+
+	d.ingress = &extv1beta1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ingressName,
+			Namespace: d.GetNamespace(),
+			Labels: map[string]string{
+				managedByLabel: managedByValue,
+			},
+			Annotations: map[string]string{},
+		},
+		Spec: extv1beta1.IngressSpec{
+			Rules: rules,
+		},
+	}
+	if d.Settings.Ingress.Class != "" {
+		d.ingress.Annotations[ingressClassKey] = d.Settings.Ingress.Class
+	}
+
+	switch d.Settings.Ingress.TLS.Mode {
+	case "":
+		return nil
+	case utils.TLSLocalCA:
+	case utils.TLSCertManager:
+		d.certificate = d.certificateSpec(hosts)
+	default:
+		return fmt.Errorf("Unknown ingress TLS mode %s", d.Settings.Ingress.TLS.Mode)
+	}
+	d.ingress.Spec.TLS = []extv1beta1.IngressTLS{
+		{Hosts: hosts, SecretName: tlsSecretName},
+	}
+
+	return nil
+}
+
+// ingressHost expands the host template of a service
+func (d *Deployer) ingressHost(template string, service string) (string, error) {
+	if template == "true" {
+		template = service + "." + envTemplate + "." + domainTemplate
+	}
+	if strings.Contains(template, domainTemplate) && d.Settings.Ingress.Domain == "" {
+		return "", fmt.Errorf("Ingress host %s needs the ingress domain setting", template)
+	}
+
+	host := strings.Replace(template, domainTemplate, d.Settings.Ingress.Domain, -1)
+
+	return d.gatewayTemplate(host), nil
+}
+
+// ingressHosts returns the hosts the ingress serves
+func (d *Deployer) ingressHosts() []string {
+	var hosts []string
+	for _, rule := range d.ingress.Spec.Rules {
+		hosts = append(hosts, rule.Host)
+	}
+
+	return hosts
+}
+
+// createIngress creates the ingress of the environment and its certificate,
+// returning the URLs of its hosts
+func (d *Deployer) createIngress() ([]string, error) {
+	if d.ingress == nil {
+		return nil, nil
+	}
+
+	if d.Settings.Ingress.TLS.Mode == utils.TLSLocalCA {
+		secret, err := d.issueCertificate(d.ingressHosts())
+		if err != nil {
+			return nil, err
+		}
+		fmt.Println("Creating secret ", secret.GetObjectMeta().GetName())
+		if _, err := d.Client.CoreV1().Secrets(d.GetNamespace()).Create(secret); err != nil {
+			return nil, err
+		}
+		fmt.Printf("Created secret %s on namespace %s \n", secret.GetObjectMeta().GetName(), d.GetNamespace())
+	}
+
+	if d.certificate != nil {
+		fmt.Println("Creating certificate ", tlsSecretName)
+		if err := d.createCertificate(); err != nil {
+			return nil, err
+		}
+		fmt.Printf("Created certificate %s on namespace %s \n", tlsSecretName, d.GetNamespace())
+	}
+
+	fmt.Println("Creating ingress ", d.ingress.GetObjectMeta().GetName())
+	result, err := d.Client.ExtensionsV1beta1().Ingresses(d.GetNamespace()).Create(d.ingress)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Printf("Created ingress %s on namespace %s \n", result.GetObjectMeta().GetName(), result.GetObjectMeta().GetNamespace())
+
+	scheme := "http"
+	if len(d.ingress.Spec.TLS) > 0 {
+		scheme = "https"
+	}
+	var urls []string
+	for _, rule := range d.ingress.Spec.Rules {
+		urls = append(urls, fmt.Sprintf("%s %s://%s/", rule.HTTP.Paths[0].Backend.ServiceName, scheme, rule.Host))
+	}
+
+	return urls, nil
+}
+
+// deleteIngress deletes the ingress of the environment and its certificate
+func (d *Deployer) deleteIngress() error {
+	if d.ingress == nil {
+		return nil
+	}
+
+	fmt.Println("Deleting ingress ", ingressName)
+	if err := d.Client.ExtensionsV1beta1().Ingresses(d.GetNamespace()).Delete(ingressName, &metav1.DeleteOptions{}); err != nil {
+		if errors.IsNotFound(err) {
+			fmt.Println(err.Error())
+		} else {
+			return err
+		}
+	} else {
+		fmt.Println("Deleted ingress ", ingressName)
+	}
+
+	if d.certificate != nil {
+		fmt.Println("Deleting certificate ", tlsSecretName)
+		if err := d.deleteCertificate(); err != nil {
+			if errors.IsNotFound(err) {
+				fmt.Println(err.Error())
+			} else {
+				return err
+			}
+		} else {
+			fmt.Println("Deleted certificate ", tlsSecretName)
+		}
+	}
+
+	// cert-manager leaves the secrets it issued behind
+	if len(d.ingress.Spec.TLS) > 0 {
+		fmt.Println("Deleting secret ", tlsSecretName)
+		if err := d.Client.CoreV1().Secrets(d.GetNamespace()).Delete(tlsSecretName, &metav1.DeleteOptions{}); err != nil {
+			if errors.IsNotFound(err) {
+				fmt.Println(err.Error())
+			} else {
+				return err
+			}
+		} else {
+			fmt.Println("Deleted secret ", tlsSecretName)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package deployer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// List writes the environments of the cluster, the namespaces managed by k8-cid,
+// with the repos they were created from and their age
+func List(c *kubernetes.Clientset, w io.Writer) error {
+	nss, err := c.CoreV1().Namespaces().List(metav1.ListOptions{
+		LabelSelector: managedByLabel + "=" + managedByValue,
+	})
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAMESPACE\tREPOS\tSTATUS\tAGE")
+	for _, ns := range nss.Items {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", ns.GetObjectMeta().GetName(), ns.Annotations[reposAnnotation],
+			ns.Status.Phase, age(ns.CreationTimestamp))
+	}
+
+	return tw.Flush()
+}
+
+// Describe writes the repos, pinned images, deployments, services and ingresses
+// of the environment
+func (d *Deployer) Describe(w io.Writer) error {
+	ns, err := d.Client.CoreV1().Namespaces().Get(d.GetNamespace(), metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(tw, "Namespace:\t%s\n", ns.GetObjectMeta().GetName())
+	fmt.Fprintf(tw, "Repos:\t%s\n", strings.Replace(ns.Annotations[reposAnnotation], ",", ", ", -1))
+	fmt.Fprintf(tw, "Status:\t%s\n", ns.Status.Phase)
+	fmt.Fprintf(tw, "Age:\t%s\n", age(ns.CreationTimestamp))
+
+	if v, ok := ns.Annotations[pinnedImagesAnnotation]; ok {
+		pins := map[string]string{}
+		if err := json.Unmarshal([]byte(v), &pins); err == nil {
+			var tagged []string
+			for k := range pins {
+				tagged = append(tagged, k)
+			}
+			sort.Strings(tagged)
+			fmt.Fprintln(tw, "Pinned images:")
+			for _, k := range tagged {
+				fmt.Fprintf(tw, "  %s\t%s\n", k, pins[k])
+			}
+		}
+	}
+
+	deployments, err := d.Client.AppsV1().Deployments(d.GetNamespace()).List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(tw, "Deployments:")
+	for _, v := range deployments.Items {
+		desired := int32(1)
+		if v.Spec.Replicas != nil {
+			desired = *v.Spec.Replicas
+		}
+		var images []string
+		for _, c := range v.Spec.Template.Spec.Containers {
+			images = append(images, c.Image)
+		}
+		fmt.Fprintf(tw, "  %s\t%d/%d ready\t%s\n", v.GetObjectMeta().GetName(), v.Status.ReadyReplicas, desired, strings.Join(images, ", "))
+	}
+
+	services, err := d.Client.CoreV1().Services(d.GetNamespace()).List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(tw, "Services:")
+	for _, v := range services.Items {
+		var ports []string
+		for _, p := range v.Spec.Ports {
+			if p.NodePort != 0 {
+				ports = append(ports, fmt.Sprintf("%d:%d", p.Port, p.NodePort))
+			} else {
+				ports = append(ports, fmt.Sprintf("%d", p.Port))
+			}
+		}
+		fmt.Fprintf(tw, "  %s\t%s\t%s\n", v.GetObjectMeta().GetName(), v.Spec.Type, strings.Join(ports, ","))
+	}
+
+	ingresses, err := d.Client.ExtensionsV1beta1().Ingresses(d.GetNamespace()).List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	if len(ingresses.Items) > 0 {
+		fmt.Fprintln(tw, "Ingresses:")
+		for _, v := range ingresses.Items {
+			scheme := "http"
+			if len(v.Spec.TLS) > 0 {
+				scheme = "https"
+			}
+			for _, r := range v.Spec.Rules {
+				if r.HTTP == nil {
+					continue
+				}
+				for _, p := range r.HTTP.Paths {
+					fmt.Fprintf(tw, "  %s\t%s://%s%s\t%s\n", v.GetObjectMeta().GetName(), scheme, r.Host, p.Path, p.Backend.ServiceName)
+				}
+			}
+		}
+	}
+
+	return tw.Flush()
+}
+
+// age formats the time since a timestamp like kubectl does, e.g. 5m or 3d
+func age(t metav1.Time) string {
+	d := time.Since(t.Time)
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+
+	return fmt.Sprintf("%dd", int(d.Hours()/24))
+}
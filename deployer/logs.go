@@ -0,0 +1,243 @@
+package deployer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Rakanixu/k8-cid/utils"
+	"golang.org/x/crypto/ssh/terminal"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// ANSI colours used to tell components apart on a terminal
+var logColours = []string{"\x1b[36m", "\x1b[33m", "\x1b[32m", "\x1b[35m", "\x1b[34m", "\x1b[31m"}
+
+const logColourReset = "\x1b[0m"
+
+type componentSelector struct {
+	component string
+	selector  labels.Selector
+}
+
+type logStreamer struct {
+	d          *Deployer
+	components []string
+	selectors  []componentSelector
+	since      time.Duration
+	colour     bool
+	colours    map[string]string
+	out        sync.Mutex
+	mu         sync.Mutex
+	streaming  map[string]bool
+}
+
+// Logs prints the logs of every container of every component running on the
+// environment namespace, prefixing each line with its component and pod name.
+// Unless previous is set, logs are followed and pods are picked up and dropped as
+// they come and go. With previous set, the logs of the last terminated instance of
+// every restarted container are printed instead.
+func (d *Deployer) Logs(components []string, since time.Duration, previous bool) error {
+	selectors, err := d.componentSelectors()
+	if err != nil {
+		return err
+	}
+
+	l := &logStreamer{
+		d:          d,
+		components: components,
+		selectors:  selectors,
+		since:      since,
+		colour:     terminal.IsTerminal(int(os.Stdout.Fd())),
+		colours:    make(map[string]string),
+		streaming:  make(map[string]bool),
+	}
+
+	if previous {
+		return l.previous()
+	}
+
+	return l.follow()
+}
+
+// componentSelectors returns the label selector of every deployment on the namespace
+func (d *Deployer) componentSelectors() ([]componentSelector, error) {
+	var selectors []componentSelector
+
+	deployments, err := d.Client.AppsV1().Deployments(d.GetNamespace()).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range deployments.Items {
+		s, err := metav1.LabelSelectorAsSelector(v.Spec.Selector)
+		if err != nil {
+			return nil, err
+		}
+		selectors = append(selectors, componentSelector{
+			component: v.GetObjectMeta().GetName(),
+			selector:  s,
+		})
+	}
+
+	return selectors, nil
+}
+
+// componentOf returns the component (deployment) a pod belongs to
+func componentOf(selectors []componentSelector, pod *apiv1.Pod) string {
+	for _, v := range selectors {
+		if v.selector.Matches(labels.Set(pod.GetObjectMeta().GetLabels())) {
+			return v.component
+		}
+	}
+
+	return pod.GetObjectMeta().GetName()
+}
+
+func (l *logStreamer) selected(component string) bool {
+	return len(l.components) == 0 || utils.Find(l.components, component) != -1
+}
+
+func (l *logStreamer) previous() error {
+	pods, err := l.d.Client.CoreV1().Pods(l.d.GetNamespace()).List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	for k := range pods.Items {
+		pod := &pods.Items[k]
+		component := componentOf(l.selectors, pod)
+		if !l.selected(component) {
+			continue
+		}
+
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.RestartCount == 0 && cs.LastTerminationState.Terminated == nil {
+				continue
+			}
+			if err := l.stream(component, pod, cs.Name, true, false); err != nil {
+				fmt.Printf("Could not get previous logs of %s/%s: %s\n", pod.GetObjectMeta().GetName(), cs.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (l *logStreamer) follow() error {
+	for {
+		w, err := l.d.Client.CoreV1().Pods(l.d.GetNamespace()).Watch(metav1.ListOptions{})
+		if err != nil {
+			return err
+		}
+
+		for ev := range w.ResultChan() {
+			if ev.Type != watch.Added && ev.Type != watch.Modified {
+				continue
+			}
+			pod, ok := ev.Object.(*apiv1.Pod)
+			if !ok {
+				continue
+			}
+
+			component := componentOf(l.selectors, pod)
+			if !l.selected(component) {
+				continue
+			}
+
+			// Start a stream for every running container not streamed yet.
+			// Restarted containers show up here again once they are running.
+			for _, cs := range pod.Status.ContainerStatuses {
+				if cs.State.Running == nil {
+					continue
+				}
+				key := fmt.Sprintf("%s/%s/%d", pod.GetObjectMeta().GetName(), cs.Name, cs.RestartCount)
+				if !l.start(key) {
+					continue
+				}
+
+				go func(key string, pod *apiv1.Pod, container string) {
+					if err := l.stream(component, pod, container, false, true); err != nil {
+						fmt.Printf("Log stream of %s/%s closed: %s\n", pod.GetObjectMeta().GetName(), container, err)
+					}
+				}(key, pod, cs.Name)
+			}
+		}
+
+		// Watches are closed by the API server from time to time, just watch again
+		w.Stop()
+	}
+}
+
+// start marks a container instance as streamed, returning false if it already was
+func (l *logStreamer) start(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.streaming[key] {
+		return false
+	}
+	l.streaming[key] = true
+
+	return true
+}
+
+func (l *logStreamer) stream(component string, pod *apiv1.Pod, container string, previous bool, follow bool) error {
+	opts := &apiv1.PodLogOptions{
+		Container: container,
+		Follow:    follow,
+		Previous:  previous,
+	}
+	if l.since > 0 {
+		seconds := int64(l.since.Seconds())
+		opts.SinceSeconds = &seconds
+	}
+
+	r, err := l.d.Client.CoreV1().Pods(pod.GetObjectMeta().GetNamespace()).GetLogs(pod.GetObjectMeta().GetName(), opts).Stream()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	prefix := fmt.Sprintf("[%s %s]", component, pod.GetObjectMeta().GetName())
+	if len(pod.Spec.Containers) > 1 {
+		prefix = fmt.Sprintf("[%s %s/%s]", component, pod.GetObjectMeta().GetName(), container)
+	}
+	if l.colour {
+		prefix = l.colourOf(component) + prefix + logColourReset
+	}
+
+	return l.copyLines(prefix, r)
+}
+
+func (l *logStreamer) copyLines(prefix string, r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		l.out.Lock()
+		fmt.Println(prefix, scanner.Text())
+		l.out.Unlock()
+	}
+
+	return scanner.Err()
+}
+
+func (l *logStreamer) colourOf(component string) string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if c, ok := l.colours[component]; ok {
+		return c
+	}
+	c := logColours[len(l.colours)%len(logColours)]
+	l.colours[component] = c
+
+	return c
+}
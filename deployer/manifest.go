@@ -0,0 +1,222 @@
+package deployer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/Rakanixu/k8-cid/schema"
+	"github.com/Rakanixu/k8-cid/utils"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	yaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// manifestDocument is a document of a manifest file, as decoded
+type manifestDocument struct {
+	// index of the document in the file, counting the ones holding only comments
+	index int
+	obj   map[string]interface{}
+}
+
+// builtinKinds are the kinds loaded from manifests, validated against their Go types
+// when the cluster schema is not available
+var builtinKinds = map[schema.GroupVersionKind]interface{}{
+	{Group: "apps", Version: "v1", Kind: "Deployment"}:                              appsv1.Deployment{},
+	{Version: "v1", Kind: "Service"}:                                                apiv1.Service{},
+	{Version: "v1", Kind: "ServiceAccount"}:                                         apiv1.ServiceAccount{},
+	{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "Role"}:               rbacv1.Role{},
+	{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "RoleBinding"}:        rbacv1.RoleBinding{},
+	{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRole"}:        rbacv1.ClusterRole{},
+	{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRoleBinding"}: rbacv1.ClusterRoleBinding{},
+}
+
+// decodeManifest decodes the document of a manifest into obj. Fields obj does not
+// have, such as misspelled ones, fail the decoding unless decoding is lenient. Only
+// one object is loaded per manifest, so manifests holding more fail too.
+func (d *Deployer) decodeManifest(f *os.File, obj interface{}) error {
+	path := f.Name()
+	b, err := ioutil.ReadAll(f)
+	if err != nil {
+		return err
+	}
+	docs, err := decodeDocuments(path, b)
+	if err != nil {
+		return err
+	}
+	if len(docs) == 0 {
+		return nil
+	}
+	if len(docs) > 1 {
+		kind, _ := docs[1].obj["kind"].(string)
+		return fmt.Errorf("%s: document %d: only one object is loaded per manifest, move the %s to a manifest of its own", path, docs[1].index, kind)
+	}
+
+	doc := docs[0]
+	translateDocument(path, doc.index, doc.obj)
+	if !d.Settings.Schema.Lenient {
+		if unknown := schema.UnknownFields(reflect.TypeOf(obj), doc.obj); len(unknown) > 0 {
+			return fmt.Errorf("%s: document %d: unknown fields %s", path, doc.index, strings.Join(unknown, ", "))
+		}
+	}
+
+	j, err := json.Marshal(doc.obj)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(j, obj); err != nil {
+		return fmt.Errorf("%s: document %d: %s", path, doc.index, err)
+	}
+
+	return nil
+}
+
+// decodeDocuments decodes the YAML documents of a manifest file, skipping the ones
+// holding only comments
+func decodeDocuments(path string, b []byte) ([]manifestDocument, error) {
+	var docs []manifestDocument
+	for k, doc := range splitYAMLDocuments(string(b)) {
+		j, err := yaml.ToJSON([]byte(doc))
+		if err != nil {
+			return nil, fmt.Errorf("%s: document %d: %s", path, k, err)
+		}
+
+		var v interface{}
+		if err := json.Unmarshal(j, &v); err != nil {
+			return nil, fmt.Errorf("%s: document %d: %s", path, k, err)
+		}
+		if v == nil {
+			continue
+		}
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s: document %d: expected an object, got %T", path, k, v)
+		}
+		docs = append(docs, manifestDocument{index: k, obj: obj})
+	}
+
+	return docs, nil
+}
+
+// ValidateSchemas validates every document of every manifest of the manifests directory
+// against the OpenAPI schema, from the offline copy in the settings, the live cluster
+// or, when the cluster does not serve it, the API types k8-cid is built with.
+// Documents of deprecated group/versions are validated as translated.
+func (d *Deployer) ValidateSchemas() error {
+	if d.Settings.Schema.Skip {
+		return nil
+	}
+
+	files, err := manifestFiles(d.Settings.Manifests)
+	if err != nil || len(files) == 0 {
+		return err
+	}
+
+	s, err := d.openAPISchema()
+	if err != nil {
+		return fmt.Errorf("Could not load the OpenAPI schema: %s", err)
+	}
+
+	var errs []string
+	var validated int
+	for _, f := range files {
+		b, err := ioutil.ReadFile(f)
+		if err != nil {
+			return err
+		}
+		docs, err := decodeDocuments(f, b)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+
+		for _, doc := range docs {
+			translateAPIVersion(doc.obj)
+			fieldErrs, err := s.Validate(doc.obj)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("%s: document %d: %s", f, doc.index, err))
+				continue
+			}
+			for _, v := range fieldErrs {
+				errs = append(errs, fmt.Sprintf("%s: document %d: %s", f, doc.index, v))
+			}
+			validated++
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("Invalid manifests:\n  %s", strings.Join(errs, "\n  "))
+	}
+	fmt.Printf("Validated %d documents of %d manifests against the OpenAPI schema\n", validated, len(files))
+
+	return nil
+}
+
+// openAPISchema returns the schema of the settings file or of the cluster. The spec
+// of the cluster is cached per cluster and server version, so it is fetched once.
+func (d *Deployer) openAPISchema() (*schema.Schema, error) {
+	if d.Settings.Schema.File != "" {
+		return schema.Read(d.Settings.Schema.File)
+	}
+
+	var version string
+	if v, err := d.Client.Discovery().ServerVersion(); err == nil {
+		version = v.GitVersion
+		b, err := utils.ReadOpenAPICache(version)
+		if err != nil {
+			return nil, err
+		}
+		if b != nil {
+			if s, err := schema.Parse(b); err == nil {
+				return s, nil
+			}
+		}
+	}
+
+	b, err := d.Client.Discovery().RESTClient().Get().
+		AbsPath("/openapi/v2").
+		SetHeader("Accept", "application/json").
+		Do().
+		Raw()
+	if err != nil {
+		fmt.Printf("Could not get the OpenAPI schema of the cluster, validating against the built-in one: %s\n", err)
+		return schema.FromTypes(builtinKinds), nil
+	}
+
+	s, err := schema.Parse(b)
+	if err != nil {
+		return nil, err
+	}
+	if version != "" {
+		if err := utils.WriteOpenAPICache(version, b); err != nil {
+			fmt.Println("Could not cache the OpenAPI schema of the cluster: ", err)
+		}
+	}
+
+	return s, nil
+}
+
+// manifestFiles lists the YAML manifests under a directory
+func manifestFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if ext := filepath.Ext(path); !info.IsDir() && (ext == ".yml" || ext == ".yaml") {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	return files, err
+}
@@ -0,0 +1,103 @@
+package deployer
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	apiv1 "k8s.io/api/core/v1"
+)
+
+const testServiceYAML = `apiVersion: v1
+kind: Service
+metadata:
+  name: mercury
+spec:
+  ports:
+  - port: 8080
+`
+
+func writeManifests(t *testing.T, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "k8-cid-manifests")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		p := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return dir
+}
+
+func TestDecodeManifest(t *testing.T) {
+	dir := writeManifests(t, map[string]string{
+		"mercury-svc.yml":   "# the service of mercury\n---\n" + testServiceYAML,
+		"venus-svc.yml":     testServiceYAML + "---\napiVersion: v1\nkind: ConfigMap\n",
+		"cerberus-svc.yml":  strings.Replace(testServiceYAML, "port:", "prot:", 1),
+		"juno-comments.yml": "# nothing\n---\n# else\n",
+	})
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		file string
+		err  string
+	}{
+		{file: "mercury-svc.yml"},
+		{file: "juno-comments.yml"},
+		{file: "venus-svc.yml", err: "venus-svc.yml: document 1: only one object is loaded per manifest, move the ConfigMap"},
+		{file: "cerberus-svc.yml", err: "cerberus-svc.yml: document 0: unknown fields .spec.ports[0].prot"},
+	}
+
+	d, done := newTestDeployer(t, newTestCluster())
+	defer done()
+	for _, test := range tests {
+		f, err := os.Open(filepath.Join(dir, test.file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		svc := &apiv1.Service{}
+		err = d.decodeManifest(f, svc)
+		f.Close()
+
+		switch {
+		case test.err == "" && err != nil:
+			t.Errorf("%s: %s", test.file, err)
+		case test.err != "" && (err == nil || !strings.Contains(err.Error(), test.err)):
+			t.Errorf("%s: got error %v, want %q", test.file, err, test.err)
+		case test.err == "" && test.file == "mercury-svc.yml" && svc.Spec.Ports[0].Port != 8080:
+			t.Errorf("%s: got %+v", test.file, svc.Spec)
+		}
+	}
+}
+
+func TestValidateSchemas(t *testing.T) {
+	dir := writeManifests(t, map[string]string{
+		"mercury-svc.yml": testServiceYAML,
+		// every document is validated, including the ones of components not deployed
+		"rbac/venus.yaml": "# comments\n---\n" + testServiceYAML + "---\napiVersion: v1\nkind: ServiceAccount\nmetadata:\n  nmae: venus\n",
+		"README.md":       "not a manifest",
+	})
+	defer os.RemoveAll(dir)
+
+	// the test cluster does not serve /openapi/v2, so the built-in schema is used
+	d, done := newTestDeployer(t, newTestCluster())
+	defer done()
+	d.Settings.Manifests = dir
+
+	err := d.ValidateSchemas()
+	want := filepath.Join(dir, "rbac/venus.yaml") + ": document 2: .metadata.nmae: unknown field"
+	if err == nil || !strings.Contains(err.Error(), want) {
+		t.Fatalf("got %v, want %q", err, want)
+	}
+	if strings.Contains(err.Error(), "mercury") || strings.Contains(err.Error(), "document 1") {
+		t.Errorf("got %s", err)
+	}
+}
@@ -0,0 +1,56 @@
+package deployer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// reposAnnotation keeps the full repo=commit set of an environment on its namespace,
+	// as the namespace name may be shortened
+	reposAnnotation = "k8-cid/repos"
+	managedByLabel  = "app.kubernetes.io/managed-by"
+	managedByValue  = "k8-cid"
+	nameHashLength  = 8
+)
+
+var (
+	invalidNameChars = regexp.MustCompile("[^a-z0-9-]+")
+	repeatedDashes   = regexp.MustCompile("-{2,}")
+)
+
+// dnsLabel turns s into a valid DNS-1123 label, as required by namespace names.
+// Dots become dashes as they always did. When anything else has to change, or the
+// result is longer than 63 characters, it is cut short and a hash of s is appended,
+// so different inputs never end up with the same name.
+func dnsLabel(s string) string {
+	label := strings.Replace(s, ".", "-", -1)
+	sanitized := strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(label), "-"), "-")
+	sanitized = repeatedDashes.ReplaceAllString(sanitized, "-")
+
+	if sanitized == label && len(sanitized) <= validation.DNS1123LabelMaxLength {
+		return sanitized
+	}
+
+	sum := sha256.Sum256([]byte(s))
+	suffix := hex.EncodeToString(sum[:])[:nameHashLength]
+	max := validation.DNS1123LabelMaxLength - nameHashLength - 1
+	if len(sanitized) > max {
+		sanitized = strings.TrimRight(sanitized[:max], "-")
+	}
+	if sanitized == "" {
+		return suffix
+	}
+
+	return sanitized + "-" + suffix
+}
+
+// derivedName names objects of the environment that are not namespaced, or are
+// referenced from outside the namespace, after the environment they belong to
+func (d *Deployer) derivedName(name string) string {
+	return dnsLabel(name + d.GetNamespace())
+}
@@ -0,0 +1,45 @@
+package deployer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+func TestDNSLabel(t *testing.T) {
+	hash := func(s string) string {
+		sum := sha256.Sum256([]byte(s))
+		return hex.EncodeToString(sum[:])[:nameHashLength]
+	}
+	long := strings.Repeat("mercury-", 10)
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"mercury", "mercury"},
+		{"mercury.v1.2", "mercury-v1-2"},
+		{"Mercury", "mercury-" + hash("Mercury")},
+		{"feature/login_form", "feature-login-form-" + hash("feature/login_form")},
+		{"--mercury--", "mercury-" + hash("--mercury--")},
+		{"___", hash("___")},
+		{long, strings.TrimRight(long[:validation.DNS1123LabelMaxLength-nameHashLength-1], "-") + "-" + hash(long)},
+	}
+
+	for _, test := range tests {
+		got := dnsLabel(test.in)
+		if got != test.want {
+			t.Errorf("%s: got %s, want %s", test.in, got, test.want)
+		}
+		if errs := validation.IsDNS1123Label(got); len(errs) > 0 {
+			t.Errorf("%s: %s is not a DNS label: %v", test.in, got, errs)
+		}
+	}
+
+	if dnsLabel("feature/a") == dnsLabel("feature_a") {
+		t.Error("different inputs share a label")
+	}
+}
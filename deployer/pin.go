@@ -0,0 +1,54 @@
+package deployer
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// pinnedImagesAnnotation records, as a JSON object, the digest every image tag of an
+// environment was pinned to
+const pinnedImagesAnnotation = "k8-cid/pinned-images"
+
+// PinDigests resolves the image of every container and init container to the digest
+// its tag points at right now, and deploys the image by digest instead, so mutable
+// tags like latest cannot change what an environment runs. The original tags and
+// their digests are recorded on the namespace and deployment annotations.
+func (d *Deployer) PinDigests() error {
+	resolved, err := d.resolveImages()
+	if err != nil {
+		return err
+	}
+
+	d.pins = map[string]string{}
+	for _, v := range resolved {
+		pinned := v.ref
+		pinned.Tag = ""
+		pinned.Digest = v.digest
+
+		tagged := v.ref.String()
+		v.container.Image = pinned.String()
+		d.pins[tagged] = v.digest
+
+		if v.deployment.pins == nil {
+			v.deployment.pins = map[string]string{}
+		}
+		v.deployment.pins[tagged] = v.digest
+		fmt.Printf("Pinned image %s to %s\n", tagged, pinned.String())
+	}
+
+	for _, deployment := range d.deployments {
+		if len(deployment.pins) == 0 {
+			continue
+		}
+		annotation, err := json.Marshal(deployment.pins)
+		if err != nil {
+			return err
+		}
+		if deployment.k8sDeployment.Annotations == nil {
+			deployment.k8sDeployment.Annotations = map[string]string{}
+		}
+		deployment.k8sDeployment.Annotations[pinnedImagesAnnotation] = string(annotation)
+	}
+
+	return nil
+}
@@ -0,0 +1,178 @@
+package deployer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Rakanixu/k8-cid/utils"
+
+	apiv1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+// defaultDeniedClusterRoles may not be bound unless the policy says otherwise
+var defaultDeniedClusterRoles = []string{"cluster-admin"}
+
+// secretVerbs read secrets
+var secretVerbs = []string{"*", "get", "list", "watch"}
+
+// policyViolation is something a component grants against the policy
+type policyViolation struct {
+	message string
+	// deniedRole violations bind a denied cluster role, refused unless the
+	// enforcement says otherwise
+	deniedRole bool
+}
+
+// CheckPolicy checks the objects built by Init against the RBAC policy, listing the
+// violations per component. Bindings to denied cluster roles fail the check unless
+// the enforcement is warn, every violation does when it is deny. Violations not
+// failing the check are only reported.
+func (d *Deployer) CheckPolicy() error {
+	policy := d.Settings.RBAC.Policy
+	switch policy.Enforcement {
+	case utils.PolicyOff:
+		return nil
+	case "", utils.PolicyWarn, utils.PolicyDeny:
+	default:
+		return fmt.Errorf("Unknown RBAC policy enforcement %s", policy.Enforcement)
+	}
+
+	denied := policy.DeniedClusterRoles
+	if len(denied) == 0 {
+		denied = defaultDeniedClusterRoles
+	}
+
+	var failures, warnings []string
+	for _, deployment := range d.deployments {
+		if utils.Find(policy.Exempt, deployment.component) != -1 {
+			continue
+		}
+
+		var failed, warned []string
+		for _, v := range policyViolations(deployment, denied) {
+			if policy.Enforcement == utils.PolicyDeny || (policy.Enforcement == "" && v.deniedRole) {
+				failed = append(failed, v.message)
+			} else {
+				warned = append(warned, v.message)
+			}
+		}
+		failures = componentReport(failures, deployment.component, failed)
+		warnings = componentReport(warnings, deployment.component, warned)
+	}
+
+	if len(warnings) > 0 {
+		fmt.Printf("RBAC policy warnings:\n  %s\n", strings.Join(warnings, "\n  "))
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("RBAC policy violations:\n  %s", strings.Join(failures, "\n  "))
+	}
+
+	return nil
+}
+
+// componentReport adds the violations of a component to a report, if any
+func componentReport(report []string, component string, violations []string) []string {
+	if len(violations) == 0 {
+		return report
+	}
+	report = append(report, component+":")
+	for _, v := range violations {
+		report = append(report, "  "+v)
+	}
+
+	return report
+}
+
+// policyViolations lists what a component grants against the policy
+func policyViolations(deployment *deployment, denied []string) []policyViolation {
+	var violations []policyViolation
+	add := func(deniedRole bool, messages ...string) {
+		for _, m := range messages {
+			violations = append(violations, policyViolation{message: m, deniedRole: deniedRole})
+		}
+	}
+
+	crb := deployment.k8sClusterRoleBinding
+	if crb.GetObjectMeta().GetName() != "" && crb.RoleRef.Kind == "ClusterRole" && utils.Find(denied, crb.RoleRef.Name) != -1 {
+		add(true, fmt.Sprintf("cluster role binding %s binds denied cluster role %s", crb.GetObjectMeta().GetName(), crb.RoleRef.Name))
+	}
+	rb := deployment.k8sRoleBinding
+	if rb.GetObjectMeta().GetName() != "" && rb.RoleRef.Kind == "ClusterRole" && utils.Find(denied, rb.RoleRef.Name) != -1 {
+		add(true, fmt.Sprintf("role binding %s binds denied cluster role %s", rb.GetObjectMeta().GetName(), rb.RoleRef.Name))
+	}
+
+	cr := deployment.k8sClusterRole
+	if cr.GetObjectMeta().GetName() != "" {
+		add(false, ruleViolations("cluster role "+cr.GetObjectMeta().GetName(), cr.Rules)...)
+
+		// A cluster role is granted cluster-wide by a cluster role binding only
+		bound := crb.GetObjectMeta().GetName() != "" && crb.RoleRef.Kind == "ClusterRole" && crb.RoleRef.Name == cr.GetObjectMeta().GetName()
+		if bound && readsSecrets(cr.Rules) {
+			add(false, fmt.Sprintf("cluster role %s grants access to secrets cluster-wide", cr.GetObjectMeta().GetName()))
+		}
+	}
+	role := deployment.k8sRole
+	if role.GetObjectMeta().GetName() != "" {
+		add(false, ruleViolations("role "+role.GetObjectMeta().GetName(), role.Rules)...)
+	}
+
+	if deployment.k8sDeployment.GetObjectMeta().GetName() != "" {
+		add(false, podViolations(&deployment.k8sDeployment.Spec.Template.Spec)...)
+	}
+
+	return violations
+}
+
+// ruleViolations reports the wildcard verbs and resources of a role
+func ruleViolations(role string, rules []rbacv1.PolicyRule) []string {
+	var violations []string
+	for _, r := range rules {
+		if utils.Find(r.Verbs, "*") != -1 {
+			violations = append(violations, fmt.Sprintf("%s grants every verb on %s", role, strings.Join(r.Resources, ",")))
+		}
+		if utils.Find(r.Resources, "*") != -1 {
+			violations = append(violations, fmt.Sprintf("%s grants %s on every resource", role, strings.Join(r.Verbs, ",")))
+		}
+	}
+
+	return violations
+}
+
+// readsSecrets tells whether the rules grant reading secrets
+func readsSecrets(rules []rbacv1.PolicyRule) bool {
+	for _, r := range rules {
+		if utils.Find(r.APIGroups, "") == -1 && utils.Find(r.APIGroups, "*") == -1 {
+			continue
+		}
+		if utils.Find(r.Resources, "secrets") == -1 && utils.Find(r.Resources, "*") == -1 {
+			continue
+		}
+		for _, v := range r.Verbs {
+			if utils.Find(secretVerbs, v) != -1 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// podViolations reports privileged containers and hostPath volumes
+func podViolations(spec *apiv1.PodSpec) []string {
+	var violations []string
+
+	containers := append(append([]apiv1.Container{}, spec.InitContainers...), spec.Containers...)
+	for _, c := range containers {
+		if c.SecurityContext != nil && c.SecurityContext.Privileged != nil && *c.SecurityContext.Privileged {
+			violations = append(violations, fmt.Sprintf("container %s is privileged", c.Name))
+		}
+	}
+	for _, v := range spec.Volumes {
+		if v.HostPath != nil {
+			violations = append(violations, fmt.Sprintf("volume %s mounts host path %s", v.Name, v.HostPath.Path))
+		}
+	}
+
+	return violations
+}
@@ -0,0 +1,142 @@
+package deployer
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Rakanixu/k8-cid/utils"
+
+	apiv1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testBindingDeployment(role string, rules []rbacv1.PolicyRule) *deployment {
+	deployment := newDeployment("kronos", "vulcan", "9d80182c")
+	deployment.k8sClusterRole = &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "kronos"}, Rules: rules}
+	deployment.k8sClusterRoleBinding = &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "kronos"},
+		RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: role},
+	}
+
+	return deployment
+}
+
+func TestPolicyViolations(t *testing.T) {
+	privileged := true
+	readSecrets := []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"get"}}}
+
+	withPod := newDeployment("mercury", "juno", "089eb18d")
+	withPod.k8sDeployment.Name = "mercury"
+	withPod.k8sDeployment.Spec.Template.Spec = apiv1.PodSpec{
+		InitContainers: []apiv1.Container{{Name: "init", SecurityContext: &apiv1.SecurityContext{Privileged: &privileged}}},
+		Containers:     []apiv1.Container{{Name: "main"}},
+		Volumes: []apiv1.Volume{
+			{Name: "docker", VolumeSource: apiv1.VolumeSource{HostPath: &apiv1.HostPathVolumeSource{Path: "/var/run/docker.sock"}}},
+			{Name: "cache", VolumeSource: apiv1.VolumeSource{EmptyDir: &apiv1.EmptyDirVolumeSource{}}},
+		},
+	}
+
+	withRole := newDeployment("venus", "juno", "089eb18d")
+	withRole.k8sRole = &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{Name: "venus"},
+		Rules:      []rbacv1.PolicyRule{{Resources: []string{"*"}, Verbs: []string{"*"}}},
+	}
+	withRole.k8sRoleBinding = &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "venus"},
+		RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "cluster-admin"},
+	}
+
+	// the role is bound to another cluster role, so it grants nothing cluster-wide
+	unbound := testBindingDeployment("view", readSecrets)
+
+	tests := []struct {
+		name       string
+		deployment *deployment
+		violations []string
+	}{
+		{
+			name:       "cluster admin",
+			deployment: testBindingDeployment("cluster-admin", nil),
+			violations: []string{"denied: cluster role binding kronos binds denied cluster role cluster-admin"},
+		},
+		{
+			name:       "secrets cluster-wide",
+			deployment: testBindingDeployment("kronos", readSecrets),
+			violations: []string{"cluster role kronos grants access to secrets cluster-wide"},
+		},
+		{
+			name:       "secrets of unbound role",
+			deployment: unbound,
+		},
+		{
+			name:       "pod",
+			deployment: withPod,
+			violations: []string{"container init is privileged", "volume docker mounts host path /var/run/docker.sock"},
+		},
+		{
+			name:       "wildcard role",
+			deployment: withRole,
+			violations: []string{
+				"denied: role binding venus binds denied cluster role cluster-admin",
+				"role venus grants every verb on *",
+				"role venus grants * on every resource",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		var got []string
+		for _, v := range policyViolations(test.deployment, defaultDeniedClusterRoles) {
+			if v.deniedRole {
+				got = append(got, "denied: "+v.message)
+			} else {
+				got = append(got, v.message)
+			}
+		}
+		if fmt.Sprint(got) != fmt.Sprint(test.violations) {
+			t.Errorf("%s: got %q, want %q", test.name, got, test.violations)
+		}
+	}
+}
+
+func TestCheckPolicy(t *testing.T) {
+	admin := testBindingDeployment("cluster-admin", nil)
+	wildcard := testBindingDeployment("kronos", []rbacv1.PolicyRule{{Resources: []string{"pods"}, Verbs: []string{"*"}}})
+
+	tests := []struct {
+		name        string
+		deployment  *deployment
+		policy      utils.RBACPolicy
+		fails       bool
+		failMessage string
+	}{
+		{name: "default denies cluster admin", deployment: admin, fails: true, failMessage: "kronos:\n    cluster role binding kronos binds denied cluster role cluster-admin"},
+		{name: "default warns about wildcards", deployment: wildcard},
+		{name: "warn", deployment: admin, policy: utils.RBACPolicy{Enforcement: utils.PolicyWarn}},
+		{name: "deny", deployment: wildcard, policy: utils.RBACPolicy{Enforcement: utils.PolicyDeny}, fails: true, failMessage: "grants every verb on pods"},
+		{name: "off", deployment: admin, policy: utils.RBACPolicy{Enforcement: utils.PolicyOff}},
+		{name: "exempt", deployment: admin, policy: utils.RBACPolicy{Exempt: []string{"kronos"}}},
+		{name: "other denied roles", deployment: admin, policy: utils.RBACPolicy{DeniedClusterRoles: []string{"edit"}}},
+		{name: "unknown enforcement", deployment: wildcard, policy: utils.RBACPolicy{Enforcement: "block"}, fails: true, failMessage: "Unknown"},
+	}
+
+	for _, test := range tests {
+		d := &Deployer{Settings: &utils.Settings{}, deployments: []*deployment{test.deployment}}
+		d.Settings.RBAC.Policy = test.policy
+
+		err := d.CheckPolicy()
+		if !test.fails {
+			if err != nil {
+				t.Errorf("%s: %s", test.name, err)
+			}
+			continue
+		}
+		if err == nil {
+			t.Errorf("%s: passed", test.name)
+		} else if !strings.Contains(err.Error(), test.failMessage) {
+			t.Errorf("%s: got %q, want %q", test.name, err, test.failMessage)
+		}
+	}
+}
@@ -0,0 +1,460 @@
+package deployer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Rakanixu/k8-cid/utils"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+type permission struct {
+	verb     string
+	group    string
+	resource string
+	// namespaced permissions are checked against the environment namespace
+	namespaced bool
+	// namespace permissions are checked against, when not the environment one
+	namespace string
+}
+
+type preflight struct {
+	failures []string
+	warnings []string
+}
+
+func (p *preflight) fail(format string, a ...interface{}) {
+	p.failures = append(p.failures, fmt.Sprintf(format, a...))
+}
+
+func (p *preflight) warn(format string, a ...interface{}) {
+	p.warnings = append(p.warnings, fmt.Sprintf(format, a...))
+}
+
+// Preflight checks the environment can be created before writing anything to the
+// cluster: the namespace name is valid, the current user is allowed to do everything
+// Create does, none of the objects already exist, the image pull secrets to copy exist,
+// and the cluster has enough free CPU and memory for the requested one. Objects
+// referenced by pods that are neither generated nor copied in are only warned about,
+// as they may be created along with the namespace. All failures are reported at once.
+func (d *Deployer) Preflight() error {
+	p := &preflight{}
+	fmt.Println("Running preflight checks for namespace ", d.GetNamespace())
+
+	for _, v := range validation.IsDNS1123Label(d.GetNamespace()) {
+		p.fail("namespace %s is not a valid name: %s", d.GetNamespace(), v)
+	}
+
+	if err := d.checkPermissions(p); err != nil {
+		return err
+	}
+	if err := d.checkExisting(p); err != nil {
+		return err
+	}
+	if err := d.checkReferences(p); err != nil {
+		return err
+	}
+	if err := d.checkCapacity(p); err != nil {
+		return err
+	}
+
+	for _, v := range p.warnings {
+		fmt.Println("Preflight warning: ", v)
+	}
+	if len(p.failures) > 0 {
+		return fmt.Errorf("Preflight checks failed:\n  %s", strings.Join(p.failures, "\n  "))
+	}
+	fmt.Println("Preflight checks passed")
+
+	return nil
+}
+
+// requiredPermissions lists what Create and Wait do for the generated objects and
+// the copied pull secrets
+func (d *Deployer) requiredPermissions() []permission {
+	perms := []permission{
+		{verb: "list", resource: "namespaces"},
+		{verb: "create", resource: "namespaces"},
+		{verb: "list", resource: "pods", namespaced: true},
+		{verb: "list", resource: "events", namespaced: true},
+		{verb: "get", group: "apps", resource: "deployments", namespaced: true},
+	}
+
+	add := func(v permission) {
+		for _, p := range perms {
+			if p == v {
+				return
+			}
+		}
+		perms = append(perms, v)
+	}
+	for _, deployment := range d.deployments {
+		if deployment.k8sServiceAccount.GetObjectMeta().GetName() != "" {
+			add(permission{verb: "create", resource: "serviceaccounts", namespaced: true})
+		}
+		if deployment.k8sRole.GetObjectMeta().GetName() != "" {
+			add(permission{verb: "create", group: "rbac.authorization.k8s.io", resource: "roles", namespaced: true})
+		}
+		if deployment.k8sRoleBinding.GetObjectMeta().GetName() != "" {
+			add(permission{verb: "create", group: "rbac.authorization.k8s.io", resource: "rolebindings", namespaced: true})
+		}
+		if deployment.k8sClusterRole.GetObjectMeta().GetName() != "" {
+			add(permission{verb: "create", group: "rbac.authorization.k8s.io", resource: "clusterroles"})
+		}
+		if deployment.k8sClusterRoleBinding.GetObjectMeta().GetName() != "" {
+			add(permission{verb: "create", group: "rbac.authorization.k8s.io", resource: "clusterrolebindings"})
+		}
+		if deployment.k8sDeployment.GetObjectMeta().GetName() != "" {
+			add(permission{verb: "create", group: "apps", resource: "deployments", namespaced: true})
+		}
+		if deployment.k8sService.GetObjectMeta().GetName() != "" {
+			add(permission{verb: "create", resource: "services", namespaced: true})
+		}
+		// Node ports in use are looked up on every namespace
+		if deployment.serviceConversion != "" && deployment.k8sService.Spec.Type == apiv1.ServiceTypeNodePort {
+			add(permission{verb: "list", resource: "services"})
+		}
+	}
+
+	if d.routesService != nil {
+		perms = append(perms, permission{verb: "create", resource: "services", namespace: d.routesService.GetObjectMeta().GetNamespace()})
+	}
+	if d.routesIngress != nil {
+		add(permission{verb: "create", group: "extensions", resource: "ingresses", namespaced: true})
+	}
+	if d.ingress != nil {
+		add(permission{verb: "create", group: "extensions", resource: "ingresses", namespaced: true})
+		if d.Settings.Ingress.TLS.Mode == utils.TLSLocalCA {
+			add(permission{verb: "create", resource: "secrets", namespaced: true})
+		}
+	}
+	if d.certificate != nil {
+		group := strings.Split(d.certificate["apiVersion"].(string), "/")[0]
+		add(permission{verb: "create", group: group, resource: "certificates", namespaced: true})
+	}
+	// Pull secrets are read from their source namespace and copied into the environment
+	if len(d.Settings.PullSecrets.Names) > 0 {
+		if src, err := d.Settings.PullSecrets.Source(); err == nil {
+			add(permission{verb: "get", resource: "secrets", namespace: src})
+		}
+		add(permission{verb: "create", resource: "secrets", namespaced: true})
+	}
+
+	return perms
+}
+
+func (d *Deployer) checkPermissions(p *preflight) error {
+	for _, v := range d.requiredPermissions() {
+		attrs := &authorizationv1.ResourceAttributes{
+			Verb:     v.verb,
+			Group:    v.group,
+			Resource: v.resource,
+		}
+		if v.namespaced {
+			attrs.Namespace = d.GetNamespace()
+		}
+		if v.namespace != "" {
+			attrs.Namespace = v.namespace
+		}
+
+		review, err := d.Client.AuthorizationV1().SelfSubjectAccessReviews().Create(&authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: attrs,
+			},
+		})
+		if err != nil {
+			return err
+		}
+		if !review.Status.Allowed && v.namespace != "" {
+			p.fail("not allowed to %s %s on namespace %s", v.verb, qualifiedResource(v), v.namespace)
+		} else if !review.Status.Allowed {
+			p.fail("not allowed to %s %s", v.verb, qualifiedResource(v))
+		}
+	}
+
+	return nil
+}
+
+func qualifiedResource(v permission) string {
+	if v.group == "" {
+		return v.resource
+	}
+
+	return v.resource + "." + v.group
+}
+
+func (d *Deployer) checkExisting(p *preflight) error {
+	exists := func(err error) (bool, error) {
+		if err == nil {
+			return true, nil
+		}
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	for _, deployment := range d.deployments {
+		if n := deployment.k8sClusterRole.GetObjectMeta().GetName(); n != "" {
+			_, err := d.Client.RbacV1().ClusterRoles().Get(n, metav1.GetOptions{})
+			if found, err := exists(err); err != nil {
+				return err
+			} else if found {
+				p.fail("cluster role %s already exists", n)
+			}
+		}
+		if n := deployment.k8sClusterRoleBinding.GetObjectMeta().GetName(); n != "" {
+			_, err := d.Client.RbacV1().ClusterRoleBindings().Get(n, metav1.GetOptions{})
+			if found, err := exists(err); err != nil {
+				return err
+			} else if found {
+				p.fail("cluster role binding %s already exists", n)
+			}
+		}
+	}
+
+	// Namespaced objects can only clash when the namespace is already there
+	_, err := d.Client.CoreV1().Namespaces().Get(d.GetNamespace(), metav1.GetOptions{})
+	if found, err := exists(err); err != nil {
+		return err
+	} else if !found {
+		return nil
+	}
+	p.warn("namespace %s already exists", d.GetNamespace())
+
+	for _, deployment := range d.deployments {
+		if n := deployment.k8sServiceAccount.GetObjectMeta().GetName(); n != "" {
+			_, err := d.Client.CoreV1().ServiceAccounts(d.GetNamespace()).Get(n, metav1.GetOptions{})
+			if found, err := exists(err); err != nil {
+				return err
+			} else if found {
+				p.fail("service account %s already exists on namespace %s", n, d.GetNamespace())
+			}
+		}
+		if n := deployment.k8sDeployment.GetObjectMeta().GetName(); n != "" {
+			_, err := d.Client.AppsV1().Deployments(d.GetNamespace()).Get(n, metav1.GetOptions{})
+			if found, err := exists(err); err != nil {
+				return err
+			} else if found {
+				p.fail("deployment %s already exists on namespace %s", n, d.GetNamespace())
+			}
+		}
+		if n := deployment.k8sService.GetObjectMeta().GetName(); n != "" {
+			_, err := d.Client.CoreV1().Services(d.GetNamespace()).Get(n, metav1.GetOptions{})
+			if found, err := exists(err); err != nil {
+				return err
+			} else if found {
+				p.fail("service %s already exists on namespace %s", n, d.GetNamespace())
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkReferences makes sure the image pull secrets to copy exist, and warns about
+// the objects referenced by the pod templates that are neither generated for the
+// environment, copied into it nor already on the namespace.
+func (d *Deployer) checkReferences(p *preflight) error {
+	generated := map[string]bool{}
+	for _, deployment := range d.deployments {
+		if n := deployment.k8sServiceAccount.GetObjectMeta().GetName(); n != "" {
+			generated["serviceaccount/"+n] = true
+		}
+	}
+
+	// Image pull secrets are copied from their source namespace
+	src, err := d.Settings.PullSecrets.Source()
+	if err != nil {
+		return err
+	}
+	for _, n := range d.Settings.PullSecrets.Names {
+		_, err := d.Client.CoreV1().Secrets(src).Get(n, metav1.GetOptions{})
+		switch {
+		case errors.IsNotFound(err):
+			p.fail("secret %s to copy does not exist on namespace %s", n, src)
+		case errors.IsForbidden(err):
+			// Reported by checkPermissions
+		case err != nil:
+			return err
+		}
+		generated["secret/"+n] = true
+	}
+
+	resolves := func(kind string, name string) (bool, error) {
+		if generated[kind+"/"+name] {
+			return true, nil
+		}
+
+		var err error
+		switch kind {
+		case "serviceaccount":
+			_, err = d.Client.CoreV1().ServiceAccounts(d.GetNamespace()).Get(name, metav1.GetOptions{})
+		case "configmap":
+			_, err = d.Client.CoreV1().ConfigMaps(d.GetNamespace()).Get(name, metav1.GetOptions{})
+		case "secret":
+			_, err = d.Client.CoreV1().Secrets(d.GetNamespace()).Get(name, metav1.GetOptions{})
+		}
+		if err == nil {
+			return true, nil
+		}
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	for _, deployment := range d.deployments {
+		spec := deployment.k8sDeployment.Spec.Template.Spec
+		refs := podReferences(spec)
+
+		for _, ref := range refs {
+			ok, err := resolves(ref.kind, ref.name)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				p.warn("%s: %s %s does not exist on namespace %s, pods will not start until it is created",
+					deployment.component, ref.kind, ref.name, d.GetNamespace())
+			}
+		}
+	}
+
+	return nil
+}
+
+type reference struct {
+	kind string
+	name string
+}
+
+// podReferences lists the service account, image pull secrets and required config maps of a pod
+func podReferences(spec apiv1.PodSpec) []reference {
+	var refs []reference
+
+	if spec.ServiceAccountName != "" && spec.ServiceAccountName != "default" {
+		refs = append(refs, reference{kind: "serviceaccount", name: spec.ServiceAccountName})
+	}
+	for _, v := range spec.ImagePullSecrets {
+		refs = append(refs, reference{kind: "secret", name: v.Name})
+	}
+	for _, v := range spec.Volumes {
+		if cm := v.ConfigMap; cm != nil && (cm.Optional == nil || !*cm.Optional) {
+			refs = append(refs, reference{kind: "configmap", name: cm.Name})
+		}
+	}
+
+	containers := append([]apiv1.Container{}, spec.InitContainers...)
+	containers = append(containers, spec.Containers...)
+	for _, c := range containers {
+		for _, e := range c.EnvFrom {
+			if cm := e.ConfigMapRef; cm != nil && (cm.Optional == nil || !*cm.Optional) {
+				refs = append(refs, reference{kind: "configmap", name: cm.Name})
+			}
+		}
+		for _, e := range c.Env {
+			if e.ValueFrom == nil {
+				continue
+			}
+			if cm := e.ValueFrom.ConfigMapKeyRef; cm != nil && (cm.Optional == nil || !*cm.Optional) {
+				refs = append(refs, reference{kind: "configmap", name: cm.Name})
+			}
+		}
+	}
+
+	return refs
+}
+
+// checkCapacity compares the resources requested by all the replicas of the environment
+// with the free resources of the schedulable cluster nodes: their allocatable resources
+// less the requests of the pods running on them. It is a sum over the cluster, not a
+// per node fit.
+func (d *Deployer) checkCapacity(p *preflight) error {
+	requested := apiv1.ResourceList{
+		apiv1.ResourceCPU:    resource.Quantity{},
+		apiv1.ResourceMemory: resource.Quantity{},
+	}
+	for _, deployment := range d.deployments {
+		replicas := int32(1)
+		if deployment.k8sDeployment.Spec.Replicas != nil {
+			replicas = *deployment.k8sDeployment.Spec.Replicas
+		}
+
+		for _, c := range deployment.k8sDeployment.Spec.Template.Spec.Containers {
+			for name, total := range requested {
+				if q, ok := c.Resources.Requests[name]; ok {
+					for i := int32(0); i < replicas; i++ {
+						total.Add(q)
+					}
+					requested[name] = total
+				}
+			}
+		}
+	}
+
+	nodes, err := d.Client.CoreV1().Nodes().List(metav1.ListOptions{})
+	if err != nil {
+		if errors.IsForbidden(err) {
+			p.warn("cannot list nodes, cluster capacity not checked")
+			return nil
+		}
+		return err
+	}
+
+	free := apiv1.ResourceList{
+		apiv1.ResourceCPU:    resource.Quantity{},
+		apiv1.ResourceMemory: resource.Quantity{},
+	}
+	schedulable := map[string]bool{}
+	for _, n := range nodes.Items {
+		if n.Spec.Unschedulable {
+			continue
+		}
+		schedulable[n.GetObjectMeta().GetName()] = true
+		for name, total := range free {
+			if q, ok := n.Status.Allocatable[name]; ok {
+				total.Add(q)
+				free[name] = total
+			}
+		}
+	}
+
+	pods, err := d.Client.CoreV1().Pods("").List(metav1.ListOptions{
+		FieldSelector: "status.phase!=" + string(apiv1.PodSucceeded) + ",status.phase!=" + string(apiv1.PodFailed),
+	})
+	if err != nil {
+		if !errors.IsForbidden(err) {
+			return err
+		}
+		p.warn("cannot list pods, capacity checked against the allocatable resources of the cluster")
+	} else {
+		for _, pod := range pods.Items {
+			if !schedulable[pod.Spec.NodeName] {
+				continue
+			}
+			for _, c := range pod.Spec.Containers {
+				for name, total := range free {
+					if q, ok := c.Resources.Requests[name]; ok {
+						total.Sub(q)
+						free[name] = total
+					}
+				}
+			}
+		}
+	}
+
+	for name, r := range requested {
+		f := free[name]
+		if r.Cmp(f) > 0 {
+			p.fail("environment requests %s %s, the cluster has %s free", r.String(), name, f.String())
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package deployer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Rakanixu/k8-cid/utils"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// clusterScopedResources cannot be granted by a namespaced role
+var clusterScopedResources = []string{
+	"*",
+	"namespaces",
+	"nodes",
+	"persistentvolumes",
+	"clusterroles",
+	"clusterrolebindings",
+	"customresourcedefinitions",
+	"apiservices",
+	"storageclasses",
+	"volumeattachments",
+	"certificatesigningrequests",
+	"podsecuritypolicies",
+	"priorityclasses",
+	"mutatingwebhookconfigurations",
+	"validatingwebhookconfigurations",
+	"componentstatuses",
+	"tokenreviews",
+	"subjectaccessreviews",
+	"selfsubjectaccessreviews",
+	"selfsubjectrulesreviews",
+}
+
+// downgradeClusterRole turns the cluster role of a component, and the cluster role
+// binding granting it, into a role and role binding on the environment namespace.
+// It does nothing and returns false unless clusterScope finds no reason not to.
+func (d *Deployer) downgradeClusterRole(deployment *deployment) bool {
+	if clusterScope(deployment) != "" {
+		return false
+	}
+	role := deployment.k8sClusterRole
+	binding := deployment.k8sClusterRoleBinding
+
+	deployment.k8sRole = &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        role.GetObjectMeta().GetName(),
+			Namespace:   d.GetNamespace(),
+			Labels:      role.GetObjectMeta().GetLabels(),
+			Annotations: role.GetObjectMeta().GetAnnotations(),
+		},
+		Rules: role.Rules,
+	}
+	deployment.k8sRoleBinding = &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        binding.GetObjectMeta().GetName(),
+			Namespace:   d.GetNamespace(),
+			Labels:      binding.GetObjectMeta().GetLabels(),
+			Annotations: binding.GetObjectMeta().GetAnnotations(),
+		},
+		Subjects: binding.Subjects,
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     role.GetObjectMeta().GetName(),
+		},
+	}
+	deployment.rbacDowngrade = fmt.Sprintf("cluster role %s and cluster role binding %s", role.GetObjectMeta().GetName(), binding.GetObjectMeta().GetName())
+	deployment.k8sClusterRole = &rbacv1.ClusterRole{}
+	deployment.k8sClusterRoleBinding = &rbacv1.ClusterRoleBinding{}
+
+	return true
+}
+
+// clusterScope returns why the cluster role of a component cannot be replaced with
+// a role on the environment namespace, or an empty string if it can. The cluster
+// role binding must grant the component cluster role, which must not be aggregated
+// nor grant cluster scoped resources or non resource URLs.
+func clusterScope(deployment *deployment) string {
+	role := deployment.k8sClusterRole
+	binding := deployment.k8sClusterRoleBinding
+
+	switch {
+	case role.GetObjectMeta().GetName() == "":
+		return "no cluster role"
+	case binding.GetObjectMeta().GetName() == "":
+		return "no cluster role binding"
+	case deployment.k8sRole.GetObjectMeta().GetName() != "" || deployment.k8sRoleBinding.GetObjectMeta().GetName() != "":
+		return "the component already has a role"
+	case binding.RoleRef.Kind != "ClusterRole" || binding.RoleRef.Name != role.GetObjectMeta().GetName():
+		return fmt.Sprintf("cluster role binding %s grants %s %s", binding.GetObjectMeta().GetName(), binding.RoleRef.Kind, binding.RoleRef.Name)
+	case role.AggregationRule != nil:
+		return fmt.Sprintf("cluster role %s is aggregated", role.GetObjectMeta().GetName())
+	}
+
+	for _, r := range role.Rules {
+		if len(r.NonResourceURLs) > 0 {
+			return fmt.Sprintf("cluster role %s grants non resource URLs %s", role.GetObjectMeta().GetName(), strings.Join(r.NonResourceURLs, ","))
+		}
+		for _, res := range r.Resources {
+			if utils.Find(clusterScopedResources, res) != -1 {
+				return fmt.Sprintf("cluster role %s grants cluster scoped resource %s", role.GetObjectMeta().GetName(), res)
+			}
+		}
+	}
+
+	return ""
+}
+
+// downgradeClusterRoles replaces the cluster roles of the components with roles
+// wherever possible and reports what was, and was not, downgraded
+func (d *Deployer) downgradeClusterRoles() {
+	var downgraded, kept []string
+	for _, deployment := range d.deployments {
+		if deployment.k8sClusterRole.GetObjectMeta().GetName() == "" && deployment.k8sClusterRoleBinding.GetObjectMeta().GetName() == "" {
+			continue
+		}
+
+		if reason := clusterScope(deployment); reason != "" {
+			kept = append(kept, fmt.Sprintf("%s: %s", deployment.component, reason))
+			continue
+		}
+		d.downgradeClusterRole(deployment)
+		downgraded = append(downgraded, fmt.Sprintf("%s: %s replaced with role %s and role binding %s", deployment.component,
+			deployment.rbacDowngrade, deployment.k8sRole.GetObjectMeta().GetName(), deployment.k8sRoleBinding.GetObjectMeta().GetName()))
+	}
+
+	if len(downgraded) > 0 {
+		fmt.Println("Downgraded cluster roles")
+		for _, v := range downgraded {
+			fmt.Println("  ", v)
+		}
+	}
+	if len(kept) > 0 {
+		fmt.Println("Kept cluster roles")
+		for _, v := range kept {
+			fmt.Println("  ", v)
+		}
+	}
+}
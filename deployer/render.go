@@ -0,0 +1,143 @@
+package deployer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/Rakanixu/k8-cid/utils"
+	"github.com/ghodss/yaml"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Render writes every object the environment is made of as a multi document YAML
+func (d *Deployer) Render(w io.Writer) error {
+	for _, obj := range d.renderObjects() {
+		b, err := yaml.Marshal(obj)
+		if err != nil {
+			return err
+		}
+		comments := renderComments(obj, d.deployments)
+		if obj == d.ingress && d.Settings.Ingress.TLS.Mode == utils.TLSLocalCA {
+			comments += fmt.Sprintf("# secret %s is issued by the local CA on create\n", tlsSecretName)
+		}
+		if _, err := fmt.Fprintf(w, "---\n%s%s", comments, b); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// renderObjects returns the generated objects, in creation order, with their type set
+func (d *Deployer) renderObjects() []interface{} {
+	ns := d.namespaceSpec()
+	ns.TypeMeta = metav1.TypeMeta{Kind: "Namespace", APIVersion: "v1"}
+	objects := []interface{}{ns}
+
+	for _, deployment := range d.deployments {
+		if deployment.k8sServiceAccount.GetObjectMeta().GetName() != "" {
+			deployment.k8sServiceAccount.TypeMeta = metav1.TypeMeta{Kind: "ServiceAccount", APIVersion: "v1"}
+			objects = append(objects, deployment.k8sServiceAccount)
+		}
+		if deployment.k8sRole.GetObjectMeta().GetName() != "" {
+			deployment.k8sRole.TypeMeta = metav1.TypeMeta{Kind: "Role", APIVersion: "rbac.authorization.k8s.io/v1"}
+			objects = append(objects, deployment.k8sRole)
+		}
+		if deployment.k8sRoleBinding.GetObjectMeta().GetName() != "" {
+			deployment.k8sRoleBinding.TypeMeta = metav1.TypeMeta{Kind: "RoleBinding", APIVersion: "rbac.authorization.k8s.io/v1"}
+			objects = append(objects, deployment.k8sRoleBinding)
+		}
+		if deployment.k8sClusterRole.GetObjectMeta().GetName() != "" {
+			deployment.k8sClusterRole.TypeMeta = metav1.TypeMeta{Kind: "ClusterRole", APIVersion: "rbac.authorization.k8s.io/v1"}
+			objects = append(objects, deployment.k8sClusterRole)
+		}
+		if deployment.k8sClusterRoleBinding.GetObjectMeta().GetName() != "" {
+			deployment.k8sClusterRoleBinding.TypeMeta = metav1.TypeMeta{Kind: "ClusterRoleBinding", APIVersion: "rbac.authorization.k8s.io/v1"}
+			objects = append(objects, deployment.k8sClusterRoleBinding)
+		}
+		if deployment.k8sDeployment.GetObjectMeta().GetName() != "" {
+			deployment.k8sDeployment.TypeMeta = metav1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"}
+			objects = append(objects, deployment.k8sDeployment)
+		}
+		if deployment.k8sService.GetObjectMeta().GetName() != "" {
+			deployment.k8sService.TypeMeta = metav1.TypeMeta{Kind: "Service", APIVersion: "v1"}
+			objects = append(objects, deployment.k8sService)
+		}
+	}
+
+	if d.routesService != nil {
+		d.routesService.TypeMeta = metav1.TypeMeta{Kind: "Service", APIVersion: "v1"}
+		objects = append(objects, d.routesService)
+	}
+	if d.routesIngress != nil {
+		d.routesIngress.TypeMeta = metav1.TypeMeta{Kind: "Ingress", APIVersion: "extensions/v1beta1"}
+		objects = append(objects, d.routesIngress)
+	}
+	if d.certificate != nil {
+		objects = append(objects, d.certificate)
+	}
+	if d.ingress != nil {
+		d.ingress.TypeMeta = metav1.TypeMeta{Kind: "Ingress", APIVersion: "extensions/v1beta1"}
+		objects = append(objects, d.ingress)
+	}
+
+	return objects
+}
+
+// namespaceSpec returns the namespace the environment is deployed on,
+// labelled as managed by k8-cid and annotated with the repos it was created from
+// and the digests its images were pinned to.
+func (d *Deployer) namespaceSpec() *apiv1.Namespace {
+	ns := &apiv1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: d.GetNamespace(),
+			Labels: map[string]string{
+				managedByLabel: managedByValue,
+			},
+			Annotations: map[string]string{
+				reposAnnotation: strings.Join(d.tags, ","),
+			},
+		},
+	}
+	if len(d.pins) > 0 {
+		pins, _ := json.Marshal(d.pins)
+		ns.Annotations[pinnedImagesAnnotation] = string(pins)
+	}
+
+	return ns
+}
+
+// renderComments reports the changes made to an object that are not obvious from
+// the object itself, such as image rewrites
+func renderComments(obj interface{}, deployments []*deployment) string {
+	s := ""
+	for _, deployment := range deployments {
+		if obj == deployment.k8sService && deployment.serviceConversion != "" {
+			s += fmt.Sprintf("# service type changed: %s\n", deployment.serviceConversion)
+		}
+		if obj == deployment.k8sRole && deployment.rbacDowngrade != "" {
+			s += fmt.Sprintf("# downgraded from %s\n", deployment.rbacDowngrade)
+		}
+		if obj != deployment.k8sDeployment {
+			continue
+		}
+		for _, v := range deployment.rewrites {
+			s += fmt.Sprintf("# image rewritten: %s\n", v)
+		}
+		var pinned []string
+		for tagged := range deployment.pins {
+			pinned = append(pinned, tagged)
+		}
+		sort.Strings(pinned)
+		for _, tagged := range pinned {
+			s += fmt.Sprintf("# image pinned: %s -> %s\n", tagged, deployment.pins[tagged])
+		}
+	}
+
+	return s
+}
@@ -0,0 +1,75 @@
+package deployer
+
+import (
+	"fmt"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// copiedFromLabel marks secrets copied into an environment, with the source namespace as value
+const copiedFromLabel = "k8-cid/copied-from"
+
+// copyPullSecrets copies the configured image pull secrets from their source namespace
+func (d *Deployer) copyPullSecrets(ns string) error {
+	src, err := d.Settings.PullSecrets.Source()
+	if err != nil {
+		return err
+	}
+	for _, n := range d.Settings.PullSecrets.Names {
+		secret, err := d.Client.CoreV1().Secrets(src).Get(n, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Copying secret %s from namespace %s\n", n, src)
+		result, err := d.Client.CoreV1().Secrets(ns).Create(&apiv1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      secret.GetObjectMeta().GetName(),
+				Namespace: ns,
+				Labels: map[string]string{
+					copiedFromLabel: src,
+				},
+			},
+			Type: secret.Type,
+			Data: secret.Data,
+		})
+		if err != nil {
+			if errors.IsAlreadyExists(err) {
+				fmt.Println(err.Error())
+				continue
+			}
+			return err
+		}
+		fmt.Printf("Copied secret %s on namespace %s \n", result.GetObjectMeta().GetName(), result.GetObjectMeta().GetNamespace())
+	}
+
+	return nil
+}
+
+// deletePullSecrets deletes the secrets copied into a namespace
+func (d *Deployer) deletePullSecrets(ns string) error {
+	secrets, err := d.Client.CoreV1().Secrets(ns).List(metav1.ListOptions{
+		LabelSelector: copiedFromLabel,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, v := range secrets.Items {
+		n := v.GetObjectMeta().GetName()
+		fmt.Println("Deleting secret ", n)
+		if err := d.Client.CoreV1().Secrets(ns).Delete(n, &metav1.DeleteOptions{}); err != nil {
+			if errors.IsNotFound(err) {
+				fmt.Println(err.Error())
+			} else {
+				return err
+			}
+		} else {
+			fmt.Println("Deleted secret ", n)
+		}
+	}
+
+	return nil
+}
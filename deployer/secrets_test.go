@@ -0,0 +1,113 @@
+package deployer
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Rakanixu/k8-cid/utils"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCopyPullSecrets(t *testing.T) {
+	cluster := newTestCluster()
+	cluster.add(t, "/api/v1/namespaces/esense/secrets/dockdev", &apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "dockdev", Namespace: "esense", Labels: map[string]string{"team": "platform"}},
+		Type:       apiv1.SecretTypeDockerConfigJson,
+		Data:       map[string][]byte{apiv1.DockerConfigJsonKey: []byte(`{"auths": {}}`)},
+	})
+	// already copied by an earlier attempt
+	cluster.add(t, "/api/v1/namespaces/env/secrets/gcr", &apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "gcr", Namespace: "env"},
+	})
+	cluster.add(t, "/api/v1/namespaces/esense/secrets/gcr", &apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "gcr", Namespace: "esense"},
+	})
+	d, done := newTestDeployer(t, cluster)
+	defer done()
+	d.Settings.PullSecrets = utils.PullSecrets{SourceNamespace: "esense", Names: []string{"dockdev", "gcr"}}
+
+	if err := d.copyPullSecrets("env"); err != nil {
+		t.Fatal(err)
+	}
+
+	secret := &apiv1.Secret{}
+	if !cluster.get(t, "/api/v1/namespaces/env/secrets/dockdev", secret) {
+		t.Fatal("dockdev not copied")
+	}
+	if secret.Namespace != "env" || secret.Type != apiv1.SecretTypeDockerConfigJson ||
+		string(secret.Data[apiv1.DockerConfigJsonKey]) != `{"auths": {}}` {
+		t.Errorf("got copy %+v", secret)
+	}
+	if got := fmt.Sprint(secret.Labels); got != "map[k8-cid/copied-from:esense]" {
+		t.Errorf("got labels %s", got)
+	}
+}
+
+func TestCopyPullSecretsMissing(t *testing.T) {
+	cluster := newTestCluster()
+	d, done := newTestDeployer(t, cluster)
+	defer done()
+
+	d.Settings.PullSecrets = utils.PullSecrets{SourceNamespace: "esense", Names: []string{"dockdev"}}
+	if err := d.copyPullSecrets("env"); err == nil {
+		t.Error("copied a secret that does not exist")
+	}
+
+	d.Settings.PullSecrets = utils.PullSecrets{Names: []string{"dockdev"}}
+	if err := d.copyPullSecrets("env"); err == nil {
+		t.Error("copied secrets without source namespace")
+	}
+	if len(cluster.requests) != 1 {
+		t.Errorf("got requests %v, want a single get", cluster.requests)
+	}
+}
+
+func TestDeletePullSecrets(t *testing.T) {
+	cluster := newTestCluster()
+	for _, s := range []*apiv1.Secret{
+		{ObjectMeta: metav1.ObjectMeta{Name: "dockdev", Namespace: "env", Labels: map[string]string{copiedFromLabel: "esense"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "gcr", Namespace: "env", Labels: map[string]string{copiedFromLabel: "default"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "tls", Namespace: "env"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "dockdev", Namespace: "esense", Labels: map[string]string{copiedFromLabel: "esense"}}},
+	} {
+		cluster.add(t, fmt.Sprintf("/api/v1/namespaces/%s/secrets/%s", s.Namespace, s.Name), s)
+	}
+	d, done := newTestDeployer(t, cluster)
+	defer done()
+
+	if err := d.deletePullSecrets("env"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "[/api/v1/namespaces/env/secrets/tls /api/v1/namespaces/esense/secrets/dockdev]"
+	if got := fmt.Sprint(cluster.paths("/api/v1/namespaces/")); got != want {
+		t.Errorf("got secrets %s, want %s", got, want)
+	}
+}
+
+func TestPullSecretPermissions(t *testing.T) {
+	d := &Deployer{Settings: &utils.Settings{}}
+	d.SetNamespace("env")
+
+	has := func(want permission) bool {
+		for _, p := range d.requiredPermissions() {
+			if p == want {
+				return true
+			}
+		}
+		return false
+	}
+	get := permission{verb: "get", resource: "secrets", namespace: "esense"}
+	create := permission{verb: "create", resource: "secrets", namespaced: true}
+
+	if has(get) || has(create) {
+		t.Error("secret permissions required without pull secrets")
+	}
+
+	d.Settings.PullSecrets = utils.PullSecrets{SourceNamespace: "esense", Names: []string{"dockdev"}}
+	if !has(get) || !has(create) {
+		t.Errorf("got permissions %v, want get secrets on esense and create secrets", d.requiredPermissions())
+	}
+}
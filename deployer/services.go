@@ -0,0 +1,169 @@
+package deployer
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const defaultNodePortRange = "30000-32767"
+
+// applyServicePolicy turns LoadBalancer services into the type the service policy
+// asks for. Services turned into NodePort get node ports allocated from a hash of
+// the environment, service and port, so the same environment always gets the same
+// ones and environments do not step on each other.
+func (d *Deployer) applyServicePolicy() error {
+	low, high, err := nodePortRange(d.Settings.Services.NodePortRange)
+	if err != nil {
+		return err
+	}
+	allocated := map[int32]bool{}
+
+	for _, deployment := range d.deployments {
+		svc := deployment.k8sService
+		if svc.GetObjectMeta().GetName() == "" || svc.Spec.Type != apiv1.ServiceTypeLoadBalancer {
+			continue
+		}
+
+		target := d.Settings.Services.LoadBalancer
+		if t, ok := d.Settings.Services.Components[deployment.component]; ok {
+			target = t
+		}
+
+		switch apiv1.ServiceType(target) {
+		case "", apiv1.ServiceTypeLoadBalancer:
+			continue
+		case apiv1.ServiceTypeNodePort:
+			svc.Spec.Type = apiv1.ServiceTypeNodePort
+			svc.Spec.LoadBalancerIP = ""
+			svc.Spec.LoadBalancerSourceRanges = nil
+			for k, p := range svc.Spec.Ports {
+				if p.NodePort != 0 {
+					allocated[p.NodePort] = true
+					continue
+				}
+				port, err := nextNodePort(d.nodePortHash(svc, p, low, high), low, high, allocated)
+				if err != nil {
+					return err
+				}
+				svc.Spec.Ports[k].NodePort = port
+			}
+		case apiv1.ServiceTypeClusterIP:
+			svc.Spec.Type = apiv1.ServiceTypeClusterIP
+			svc.Spec.LoadBalancerIP = ""
+			svc.Spec.LoadBalancerSourceRanges = nil
+			svc.Spec.ExternalTrafficPolicy = ""
+			for k := range svc.Spec.Ports {
+				svc.Spec.Ports[k].NodePort = 0
+			}
+		default:
+			return fmt.Errorf("%s: unknown service type %s", deployment.component, target)
+		}
+
+		deployment.serviceConversion = fmt.Sprintf("%s -> %s", apiv1.ServiceTypeLoadBalancer, svc.Spec.Type)
+		fmt.Printf("Service %s turned from %s\n", svc.GetObjectMeta().GetName(), deployment.serviceConversion)
+	}
+
+	return nil
+}
+
+// reallocateNodePorts moves the node ports allocated by the service policy that
+// are already taken on the cluster to the next free ones
+func (d *Deployer) reallocateNodePorts() error {
+	needed := false
+	for _, deployment := range d.deployments {
+		needed = needed || (deployment.serviceConversion != "" && deployment.k8sService.Spec.Type == apiv1.ServiceTypeNodePort)
+	}
+	if !needed {
+		return nil
+	}
+
+	low, high, err := nodePortRange(d.Settings.Services.NodePortRange)
+	if err != nil {
+		return err
+	}
+
+	svcs, err := d.Client.CoreV1().Services("").List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	used := map[int32]bool{}
+	for _, svc := range svcs.Items {
+		for _, p := range svc.Spec.Ports {
+			if p.NodePort != 0 {
+				used[p.NodePort] = true
+			}
+		}
+	}
+
+	for _, deployment := range d.deployments {
+		if deployment.serviceConversion == "" || deployment.k8sService.Spec.Type != apiv1.ServiceTypeNodePort {
+			continue
+		}
+		for k, p := range deployment.k8sService.Spec.Ports {
+			if used[p.NodePort] {
+				port, err := nextNodePort(p.NodePort, low, high, used)
+				if err != nil {
+					return err
+				}
+				deployment.k8sService.Spec.Ports[k].NodePort = port
+				fmt.Printf("Node port %d of service %s taken, using %d\n", p.NodePort,
+					deployment.k8sService.GetObjectMeta().GetName(), deployment.k8sService.Spec.Ports[k].NodePort)
+			} else {
+				used[p.NodePort] = true
+			}
+		}
+	}
+
+	return nil
+}
+
+func (d *Deployer) nodePortHash(svc *apiv1.Service, p apiv1.ServicePort, low int32, high int32) int32 {
+	h := fnv.New32a()
+	fmt.Fprintf(h, "%s/%s/%s/%d", d.GetNamespace(), svc.GetObjectMeta().GetName(), p.Name, p.Port)
+
+	return low + int32(h.Sum32()%uint32(high-low+1))
+}
+
+// nextNodePort returns the first port not taken from start on, wrapping around the range.
+// It fails when every port of the range is taken.
+func nextNodePort(start int32, low int32, high int32, taken map[int32]bool) (int32, error) {
+	port := start
+	for taken[port] {
+		port++
+		if port > high {
+			port = low
+		}
+		if port == start {
+			return 0, fmt.Errorf("Node port range %d-%d is full", low, high)
+		}
+	}
+	taken[port] = true
+
+	return port, nil
+}
+
+func nodePortRange(r string) (int32, int32, error) {
+	if r == "" {
+		r = defaultNodePortRange
+	}
+
+	parts := strings.Split(r, "-")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("Invalid node port range %s", r)
+	}
+	low, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("Invalid node port range %s", r)
+	}
+	high, err := strconv.Atoi(parts[1])
+	if err != nil || high < low {
+		return 0, 0, fmt.Errorf("Invalid node port range %s", r)
+	}
+
+	return int32(low), int32(high), nil
+}
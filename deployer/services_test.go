@@ -0,0 +1,62 @@
+package deployer
+
+import "testing"
+
+func TestNextNodePort(t *testing.T) {
+	taken := map[int32]bool{30001: true, 30002: true, 30004: true}
+
+	tests := []struct {
+		start int32
+		want  int32
+	}{
+		{30000, 30000},
+		{30001, 30003},
+		{30004, 30005},
+		{30005, 30006},
+		{30006, 30007},
+		{30007, 30008},
+		{30008, 30009},
+		{30009, 30010},
+	}
+
+	for _, test := range tests {
+		got, err := nextNodePort(test.start, 30000, 30010, taken)
+		if err != nil {
+			t.Fatalf("%d: %s", test.start, err)
+		}
+		if got != test.want {
+			t.Errorf("%d: got %d, want %d", test.start, got, test.want)
+		}
+		if !taken[got] {
+			t.Errorf("%d: %d not marked taken", test.start, got)
+		}
+	}
+
+	// every port of the range is allocated by now
+	if got, err := nextNodePort(30010, 30000, 30010, taken); err == nil {
+		t.Errorf("allocated %d from a full range", got)
+	}
+}
+
+func TestNextNodePortWraps(t *testing.T) {
+	got, err := nextNodePort(30009, 30000, 30010, map[int32]bool{30009: true, 30010: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != 30000 {
+		t.Errorf("got %d, want 30000", got)
+	}
+}
+
+func TestNodePortRange(t *testing.T) {
+	low, high, err := nodePortRange("")
+	if err != nil || low != 30000 || high != 32767 {
+		t.Errorf("default range: got %d-%d, %v", low, high, err)
+	}
+
+	for _, r := range []string{"30000", "a-b", "32000-31000", "30000-32767-1"} {
+		if _, _, err := nodePortRange(r); err == nil {
+			t.Errorf("%s: accepted", r)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package deployer
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Rakanixu/k8-cid/utils"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// SyncSharedConfig refreshes the local cache of the configuration shared on the
+// cluster, see utils.UseCluster. The cache is kept when the cluster cannot be read,
+// and dropped when nothing is shared.
+func SyncSharedConfig(c *kubernetes.Clientset) error {
+	s, err := utils.ReadSettings()
+	if err != nil {
+		return err
+	}
+	if s.Shared.Namespace == "" {
+		return utils.WriteSharedCache(nil)
+	}
+
+	ns, name := s.Shared.Namespace, s.Shared.ConfigMapName()
+	cm, err := c.CoreV1().ConfigMaps(ns).Get(name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		fmt.Printf("No shared configuration %s/%s, publish it with config publish\n", ns, name)
+		return utils.WriteSharedCache(nil)
+	}
+	if err != nil {
+		fmt.Println("Could not read the shared configuration, using the cached one: ", err)
+		return nil
+	}
+
+	shared := &utils.SharedConfig{Source: ns + "/" + name}
+	if v, ok := cm.Data[utils.SharedReposKey]; ok {
+		if err := json.Unmarshal([]byte(v), &shared.Repos); err != nil {
+			return fmt.Errorf("Shared configuration %s: %s: %s", shared.Source, utils.SharedReposKey, err)
+		}
+	}
+	if v, ok := cm.Data[utils.SharedSettingsKey]; ok {
+		if err := json.Unmarshal([]byte(v), &shared.Settings); err != nil {
+			return fmt.Errorf("Shared configuration %s: %s: %s", shared.Source, utils.SharedSettingsKey, err)
+		}
+	}
+
+	return utils.WriteSharedCache(shared)
+}
+
+// PublishSharedConfig publishes the repos and the settings of a file as the
+// configuration shared on the cluster, see utils.PublishedConfig, creating the control
+// namespace if needed. Without settings file the shared settings are kept.
+func PublishSharedConfig(c *kubernetes.Clientset, settingsFile string) error {
+	if err := utils.ValidateConfig(); err != nil {
+		return err
+	}
+
+	s, err := utils.ReadSettings()
+	if err != nil {
+		return err
+	}
+	if s.Shared.Namespace == "" {
+		return fmt.Errorf("Set the shared namespace to publish the configuration to")
+	}
+	repos, settings, err := utils.PublishedConfig(settingsFile)
+	if err != nil {
+		return err
+	}
+
+	r, err := json.MarshalIndent(repos, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	ns, name := s.Shared.Namespace, s.Shared.ConfigMapName()
+	if _, err := c.CoreV1().Namespaces().Get(ns, metav1.GetOptions{}); errors.IsNotFound(err) {
+		fmt.Println("Creating namespace ", ns)
+		if _, err := c.CoreV1().Namespaces().Create(&apiv1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: ns}}); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+
+	cm := &apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: ns,
+			Labels: map[string]string{
+				managedByLabel: managedByValue,
+			},
+		},
+		Data: map[string]string{
+			utils.SharedReposKey: string(r),
+		},
+	}
+	if settings != nil {
+		b, err := json.MarshalIndent(settings, "", "  ")
+		if err != nil {
+			return err
+		}
+		cm.Data[utils.SharedSettingsKey] = string(b)
+	}
+
+	existing, err := c.CoreV1().ConfigMaps(ns).Get(name, metav1.GetOptions{})
+	switch {
+	case errors.IsNotFound(err):
+		if _, err := c.CoreV1().ConfigMaps(ns).Create(cm); err != nil {
+			return err
+		}
+	case err != nil:
+		return err
+	default:
+		if v, ok := existing.Data[utils.SharedSettingsKey]; ok && settings == nil {
+			cm.Data[utils.SharedSettingsKey] = v
+		}
+		cm.ResourceVersion = existing.ResourceVersion
+		if _, err := c.CoreV1().ConfigMaps(ns).Update(cm); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("Published shared configuration %s/%s\n", ns, name)
+
+	return SyncSharedConfig(c)
+}
@@ -0,0 +1,85 @@
+package deployer
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// transformDeployments applies the matching transforms to every deployment
+func (d *Deployer) transformDeployments() error {
+	for _, deployment := range d.deployments {
+		for _, t := range d.Settings.Transforms {
+			if t.Repo != "" && t.Repo != deployment.repo {
+				continue
+			}
+			if t.Component != "" && t.Component != deployment.component {
+				continue
+			}
+
+			spec := &deployment.k8sDeployment.Spec
+			pod := &spec.Template.Spec
+
+			if t.RemoveNodeSelector {
+				pod.NodeSelector = nil
+			}
+			for k, v := range t.NodeSelector {
+				if pod.NodeSelector == nil {
+					pod.NodeSelector = map[string]string{}
+				}
+				pod.NodeSelector[k] = v
+			}
+
+			if t.RemoveTolerations {
+				pod.Tolerations = nil
+			}
+			pod.Tolerations = append(pod.Tolerations, t.Tolerations...)
+
+			if t.Replicas != nil {
+				replicas := *t.Replicas
+				spec.Replicas = &replicas
+			}
+
+			switch appsv1.DeploymentStrategyType(t.Strategy) {
+			case "":
+			case appsv1.RecreateDeploymentStrategyType:
+				spec.Strategy = appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType}
+			case appsv1.RollingUpdateDeploymentStrategyType:
+				if spec.Strategy.Type != appsv1.RollingUpdateDeploymentStrategyType {
+					spec.Strategy = appsv1.DeploymentStrategy{Type: appsv1.RollingUpdateDeploymentStrategyType}
+				}
+			default:
+				return fmt.Errorf("%s: unknown deployment strategy %s", deployment.component, t.Strategy)
+			}
+
+			if t.RequestsScale > 0 {
+				for k := range pod.InitContainers {
+					scaleRequests(&pod.InitContainers[k].Resources, t.RequestsScale)
+				}
+				for k := range pod.Containers {
+					scaleRequests(&pod.Containers[k].Resources, t.RequestsScale)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// scaleRequests multiplies the CPU and memory requests, capping them at their limits
+func scaleRequests(r *apiv1.ResourceRequirements, scale float64) {
+	for _, name := range []apiv1.ResourceName{apiv1.ResourceCPU, apiv1.ResourceMemory} {
+		q, ok := r.Requests[name]
+		if !ok {
+			continue
+		}
+
+		scaled := resource.NewMilliQuantity(int64(float64(q.MilliValue())*scale), q.Format)
+		if limit, ok := r.Limits[name]; ok && scaled.Cmp(limit) > 0 {
+			scaled = limit.Copy()
+		}
+		r.Requests[name] = *scaled
+	}
+}
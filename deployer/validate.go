@@ -0,0 +1,101 @@
+package deployer
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Rakanixu/k8-cid/policy"
+)
+
+// Validate validates the manifests against the OpenAPI schema, then evaluates the
+// validation rules against every generated object, reporting the violations per
+// object. It fails when any rule of fail severity is violated.
+func (d *Deployer) Validate() error {
+	if err := d.ValidateSchemas(); err != nil {
+		return err
+	}
+
+	rules := d.Settings.Validation.Rules
+	for _, f := range d.Settings.Validation.Files {
+		r, err := policy.ReadRules(f)
+		if err != nil {
+			return err
+		}
+		rules = append(rules, r...)
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+
+	engine, err := policy.NewEngine(rules)
+	if err != nil {
+		return err
+	}
+
+	var warnings, failures []string
+	for _, obj := range d.renderObjects() {
+		m, err := toMap(obj)
+		if err != nil {
+			return err
+		}
+
+		component, repo := d.ownerOf(obj)
+		for _, v := range engine.Evaluate(m, component, repo, d.GetNamespace()) {
+			s := fmt.Sprintf("%s: %s [%s]", objectName(m), v.Message, v.Rule)
+			if v.Severity == policy.SeverityFail {
+				failures = append(failures, s)
+			} else {
+				warnings = append(warnings, s)
+			}
+		}
+	}
+
+	for _, v := range warnings {
+		fmt.Println("Validation warning: ", v)
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("Validation failed:\n  %s", strings.Join(failures, "\n  "))
+	}
+	fmt.Printf("Validated %d rules\n", len(rules))
+
+	return nil
+}
+
+// toMap turns a typed object into its JSON representation
+func toMap(obj interface{}) (map[string]interface{}, error) {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	m := map[string]interface{}{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// ownerOf returns the component and repo a generated object belongs to, if any
+func (d *Deployer) ownerOf(obj interface{}) (string, string) {
+	for _, deployment := range d.deployments {
+		switch obj {
+		case deployment.k8sDeployment, deployment.k8sService, deployment.k8sServiceAccount,
+			deployment.k8sClusterRole, deployment.k8sClusterRoleBinding, deployment.k8sRole, deployment.k8sRoleBinding:
+			return deployment.component, deployment.repo
+		}
+	}
+
+	return "", ""
+}
+
+// objectName names an object as Kind/name for reports
+func objectName(m map[string]interface{}) string {
+	kind, _ := m["kind"].(string)
+	name := ""
+	if meta, ok := m["metadata"].(map[string]interface{}); ok {
+		name, _ = meta["name"].(string)
+	}
+
+	return kind + "/" + name
+}
@@ -0,0 +1,113 @@
+package deployer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Rakanixu/k8-cid/image"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VerifyImages checks the image of every container and init container of the
+// environment exists on its registry, reporting the digest each one resolves to.
+// Images that cannot be found are listed by component.
+func (d *Deployer) VerifyImages() error {
+	_, err := d.resolveImages()
+	return err
+}
+
+// resolvedImage is an image of a container with the digest its tag resolves to
+type resolvedImage struct {
+	deployment *deployment
+	container  *apiv1.Container
+	ref        image.Reference
+	digest     string
+}
+
+// resolveImages resolves the image of every container and init container of the
+// environment to a digest, failing with all the images that cannot be resolved
+func (d *Deployer) resolveImages() ([]resolvedImage, error) {
+	r, err := d.registry()
+	if err != nil {
+		return nil, err
+	}
+
+	var resolved []resolvedImage
+	var failures []string
+	for _, deployment := range d.deployments {
+		spec := &deployment.k8sDeployment.Spec.Template.Spec
+
+		for _, containers := range [][]apiv1.Container{spec.InitContainers, spec.Containers} {
+			for k := range containers {
+				c := &containers[k]
+				ref, err := image.Parse(c.Image)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %s", deployment.component, err)
+				}
+
+				digest, err := r.Resolve(ref)
+				if err == image.ErrNotFound {
+					failures = append(failures, fmt.Sprintf("%s: image %s of container %s does not exist", deployment.component, c.Image, c.Name))
+					continue
+				} else if err != nil {
+					failures = append(failures, fmt.Sprintf("%s: could not verify image %s of container %s: %s", deployment.component, c.Image, c.Name, err))
+					continue
+				}
+				fmt.Printf("Image %s resolves to %s\n", c.Image, digest)
+
+				resolved = append(resolved, resolvedImage{
+					deployment: deployment,
+					container:  c,
+					ref:        ref,
+					digest:     digest,
+				})
+			}
+		}
+	}
+
+	if len(failures) > 0 {
+		return nil, fmt.Errorf("Image verification failed:\n  %s", strings.Join(failures, "\n  "))
+	}
+
+	return resolved, nil
+}
+
+// registry returns a registry client authenticated with the user docker config and
+// the image pull secrets copied into environments, which take precedence
+func (d *Deployer) registry() (*image.Registry, error) {
+	creds, err := image.UserDockerConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	src, err := d.Settings.PullSecrets.Source()
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range d.Settings.PullSecrets.Names {
+		secret, err := d.Client.CoreV1().Secrets(src).Get(n, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+
+		data, ok := secret.Data[apiv1.DockerConfigJsonKey]
+		if !ok {
+			data, ok = secret.Data[apiv1.DockerConfigKey]
+		}
+		if !ok {
+			continue
+		}
+
+		secretCreds, err := image.DockerConfig(data)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid docker config on secret %s: %s", n, err)
+		}
+		for k, v := range secretCreds {
+			creds[k] = v
+		}
+	}
+
+	return image.NewRegistry(creds, d.Settings.Images.InsecureRegistries), nil
+}
@@ -0,0 +1,91 @@
+package deployer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const waitInterval = 3 * time.Second
+
+// Wait blocks until every deployment of the environment is available. Pods of the
+// components not ready yet are inspected on every check, failing fast with an
+// explanation per component on failures waiting will not fix, such as images that
+// cannot be pulled or crash loops. Once the timeout expires the error names the
+// components still not ready and why.
+func (d *Deployer) Wait(timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	fmt.Println("Waiting for components to be ready")
+
+	for {
+		var pending []string
+		var problems []podProblem
+		fatal := false
+
+		events, err := d.Client.CoreV1().Events(d.GetNamespace()).List(metav1.ListOptions{})
+		if err != nil {
+			return err
+		}
+
+		for _, deployment := range d.deployments {
+			n := deployment.k8sDeployment.GetObjectMeta().GetName()
+			if n == "" {
+				continue
+			}
+
+			live, err := d.Client.AppsV1().Deployments(d.GetNamespace()).Get(n, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			if deploymentReady(live) {
+				continue
+			}
+			pending = append(pending, deployment.component)
+
+			p, err := d.diagnose(deployment, events.Items)
+			if err != nil {
+				return err
+			}
+			for _, v := range p {
+				fatal = fatal || v.fatal
+			}
+			problems = append(problems, p...)
+		}
+
+		if len(pending) == 0 {
+			fmt.Println("All components ready")
+			return nil
+		}
+		if fatal {
+			return fmt.Errorf("Components failed to start:%s", formatProblems(problems))
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("Timed out after %s waiting for components %s%s", timeout, strings.Join(pending, ", "), formatProblems(problems))
+		}
+
+		time.Sleep(waitInterval)
+	}
+}
+
+func formatProblems(problems []podProblem) string {
+	s := ""
+	for _, v := range problems {
+		s += "\n  " + v.String()
+	}
+
+	return s
+}
+
+func deploymentReady(dep *appsv1.Deployment) bool {
+	replicas := int32(1)
+	if dep.Spec.Replicas != nil {
+		replicas = *dep.Spec.Replicas
+	}
+
+	return dep.Status.ObservedGeneration >= dep.GetObjectMeta().GetGeneration() &&
+		dep.Status.UpdatedReplicas >= replicas &&
+		dep.Status.AvailableReplicas >= replicas
+}
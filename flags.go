@@ -0,0 +1,280 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Rakanixu/k8-cid/deployer"
+	"github.com/Rakanixu/k8-cid/utils"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// envPrefix of the environment variables flag defaults are read from
+const envPrefix = "K8CID_"
+
+var repoCommitRegexp = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*=[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// repoFlags are repo=commit pairs, given repeated or comma separated
+type repoFlags struct {
+	values []string
+	// defaulted values, from the environment, are replaced by the first flag
+	defaulted bool
+}
+
+func (r *repoFlags) String() string {
+	return strings.Join(r.values, ",")
+}
+
+func (r *repoFlags) Set(value string) error {
+	if r.defaulted {
+		r.values, r.defaulted = nil, false
+	}
+	for _, v := range strings.Split(value, ",") {
+		v = strings.TrimSpace(v)
+		if !repoCommitRegexp.MatchString(v) {
+			return fmt.Errorf("invalid %q, expected repo=commit", v)
+		}
+		r.values = append(r.values, v)
+	}
+
+	return nil
+}
+
+// options are the values of the flags of every command
+type options struct {
+	kubeconfig string
+	repos      repoFlags
+
+	// settings overrides
+	pullSecrets           string
+	pullSecretsFrom       string
+	loadBalancer          string
+	ingressDomain         string
+	downgradeClusterRoles bool
+	schema                string
+	rules                 string
+	rbacPolicy            string
+	sharedGateway         bool
+
+	wait             time.Duration
+	collectOnFailure bool
+	verifyImages     bool
+	pinDigests       bool
+	skipPreflight    bool
+	output           string
+
+	services   string
+	components string
+	since      time.Duration
+	previous   bool
+}
+
+func clusterFlags(fs *flag.FlagSet, o *options) {
+	kubeconfig := ""
+	if home := utils.HomeDir(); home != "" {
+		kubeconfig = filepath.Join(home, ".kube", "config")
+	}
+	fs.StringVar(&o.kubeconfig, "kubeconfig", kubeconfig, "absolute path to the kubeconfig file")
+}
+
+func reposFlags(fs *flag.FlagSet, o *options) {
+	fs.Var(&o.repos, "repos", "repo=commit of every repository of the environment, repeated or comma separated")
+}
+
+func settingsFlags(fs *flag.FlagSet, o *options) {
+	fs.StringVar(&o.pullSecrets, "pull-secrets", "", "(optional) comma separated image pull secrets to copy into the environment namespace")
+	fs.StringVar(&o.pullSecretsFrom, "pull-secrets-from", "", "(optional) namespace the image pull secrets are copied from")
+	fs.StringVar(&o.loadBalancer, "load-balancer", "", "(optional) type LoadBalancer services are turned into, NodePort or ClusterIP")
+	fs.StringVar(&o.ingressDomain, "ingress-domain", "", "(optional) domain of the hostnames services annotated with k8-cid/ingress are exposed on")
+	fs.BoolVar(&o.downgradeClusterRoles, "downgrade-cluster-roles", false, "(optional) replace cluster roles not needing cluster scope with roles on the environment namespace")
+	fs.BoolVar(&o.sharedGateway, "shared-gateway", false, "(optional) route the environment through the shared gateway instead of deploying its own")
+}
+
+func validationFlags(fs *flag.FlagSet, o *options) {
+	fs.StringVar(&o.schema, "schema", "", "(optional) offline copy of the Kubernetes OpenAPI v2 spec manifests are validated against, instead of the cluster one")
+	fs.StringVar(&o.rules, "rules", "", "(optional) comma separated files of validation rules")
+	fs.StringVar(&o.rbacPolicy, "rbac-policy", "", "(optional) enforcement of the RBAC policy checked before create: deny, warn or off. By default only bindings to denied cluster roles are refused")
+}
+
+func pinFlags(fs *flag.FlagSet, o *options) {
+	fs.BoolVar(&o.pinDigests, "pin-digests", false, "(optional) deploy images by the digest their tags resolve to")
+}
+
+func createFlags(fs *flag.FlagSet, o *options) {
+	fs.DurationVar(&o.wait, "wait", 5*time.Minute, "(optional) wait up to this long for every component to be ready, failing fast when pods cannot start. 0 disables it")
+	fs.BoolVar(&o.collectOnFailure, "collect-on-failure", false, "(optional) collect a diagnostics bundle when components do not get ready")
+	fs.BoolVar(&o.verifyImages, "verify-images", false, "(optional) check every image exists on its registry before deploying")
+	fs.BoolVar(&o.skipPreflight, "skip-preflight", false, "(optional) skip the preflight checks")
+	outputFlags(fs, o)
+}
+
+func outputFlags(fs *flag.FlagSet, o *options) {
+	fs.StringVar(&o.output, "output", "", "(optional) path of the diagnostics bundle, <namespace>-<timestamp>.tar.gz by default")
+}
+
+// envDefaults sets the flag defaults from K8CID_* environment variables, e.g.
+// K8CID_KUBECONFIG for -kubeconfig or K8CID_PULL_SECRETS for -pull-secrets
+func envDefaults(fs *flag.FlagSet) error {
+	var err error
+	fs.VisitAll(func(f *flag.Flag) {
+		v, ok := os.LookupEnv(envName(f.Name))
+		if !ok || err != nil {
+			return
+		}
+		if e := f.Value.Set(v); e != nil {
+			err = fmt.Errorf("Invalid %s: %s", envName(f.Name), e)
+			return
+		}
+		if r, ok := f.Value.(*repoFlags); ok {
+			r.defaulted = true
+		}
+		f.DefValue = v
+	})
+
+	return err
+}
+
+func envName(flag string) string {
+	return envPrefix + strings.ToUpper(strings.Replace(flag, "-", "_", -1))
+}
+
+// client returns the clientset of the current kubeconfig context, refreshing the
+// configuration shared on its cluster
+func (o *options) client() (*kubernetes.Clientset, error) {
+	config, err := clientcmd.BuildConfigFromFlags("", o.kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	utils.UseCluster(config.Host)
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := deployer.SyncSharedConfig(clientset); err != nil {
+		return nil, err
+	}
+
+	return clientset, nil
+}
+
+// useCluster layers in the cached configuration shared on the cluster of the current
+// kubeconfig context, if any, without connecting to it
+func (o *options) useCluster() {
+	if config, err := clientcmd.BuildConfigFromFlags("", o.kubeconfig); err == nil {
+		utils.UseCluster(config.Host)
+	}
+}
+
+// environment returns the deployer of the environment given by -repos, or by its
+// namespace as the only argument when the command accepts it. Repos defaulted from the
+// environment are ignored when the namespace is given.
+func (o *options) environment(args []string, byNamespace bool) (*deployer.Deployer, error) {
+	if byNamespace && len(args) == 1 && o.repos.defaulted {
+		o.repos.values, o.repos.defaulted = nil, false
+	}
+
+	switch {
+	case byNamespace && len(args) == 1 && len(o.repos.values) == 0:
+	case len(args) > 0:
+		return nil, usageError{fmt.Sprintf("unexpected arguments %s", strings.Join(args, " "))}
+	case len(o.repos.values) == 0:
+		return nil, usageError{"-repos is required"}
+	}
+
+	clientset, err := o.client()
+	if err != nil {
+		return nil, err
+	}
+	if err := checkRepos(o.repos.values); err != nil {
+		return nil, err
+	}
+
+	d, err := deployer.NewDeployer(clientset, o.repos.values)
+	if err != nil {
+		return nil, err
+	}
+	o.override(d.Settings)
+
+	if len(args) == 1 {
+		d.SetNamespace(args[0])
+		return d, nil
+	}
+	if err := d.Init(); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// checkRepos refuses repos the configuration does not know and repos given twice
+func checkRepos(repoCommits []string) error {
+	if len(repoCommits) == 0 {
+		return nil
+	}
+
+	repos, _, err := utils.Repos()
+	if err != nil {
+		return err
+	}
+
+	seen := map[string]bool{}
+	for _, v := range repoCommits {
+		repo := strings.Split(v, "=")[0]
+		if _, ok := repos[repo]; !ok {
+			return fmt.Errorf("Unknown repo %s, see config show", repo)
+		}
+		if seen[repo] {
+			return fmt.Errorf("Repo %s given twice", repo)
+		}
+		seen[repo] = true
+	}
+
+	return nil
+}
+
+// override sets the settings given as flags, which take precedence over saved ones
+func (o *options) override(s *utils.Settings) {
+	if o.pullSecrets != "" {
+		s.PullSecrets.Names = strings.Split(o.pullSecrets, ",")
+	}
+	if o.pullSecretsFrom != "" {
+		s.PullSecrets.SourceNamespace = o.pullSecretsFrom
+	}
+	if o.loadBalancer != "" {
+		s.Services.LoadBalancer = o.loadBalancer
+	}
+	if o.sharedGateway {
+		s.Gateway.Shared = true
+	}
+	if o.downgradeClusterRoles {
+		s.RBAC.DowngradeClusterRoles = true
+	}
+	if o.schema != "" {
+		s.Schema.File = o.schema
+	}
+	if o.rules != "" {
+		s.Validation.Files = append(s.Validation.Files, strings.Split(o.rules, ",")...)
+	}
+	if o.rbacPolicy != "" {
+		s.RBAC.Policy.Enforcement = o.rbacPolicy
+	}
+	if o.ingressDomain != "" {
+		s.Ingress.Domain = o.ingressDomain
+	}
+}
+
+// usageError is a misuse of a command, reported with its usage
+type usageError struct {
+	msg string
+}
+
+func (e usageError) Error() string {
+	return e.msg
+}
@@ -0,0 +1,137 @@
+package image
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Credential authenticates against a registry
+type Credential struct {
+	Username string
+	Password string
+}
+
+func (c Credential) basic() string {
+	return base64.StdEncoding.EncodeToString([]byte(c.Username + ":" + c.Password))
+}
+
+type dockerAuth struct {
+	Auth     string `json:"auth"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type dockerConfig struct {
+	Auths       map[string]dockerAuth `json:"auths"`
+	CredsStore  string                `json:"credsStore"`
+	CredHelpers map[string]string     `json:"credHelpers"`
+}
+
+// DockerConfig parses docker credentials by registry domain. It accepts the
+// ~/.docker/config.json format, also used by kubernetes.io/dockerconfigjson secrets,
+// and the legacy .dockercfg one, used by kubernetes.io/dockercfg secrets. Credential
+// helpers are queried for the registries they are configured for.
+func DockerConfig(b []byte) (map[string]Credential, error) {
+	config := dockerConfig{}
+	if err := json.Unmarshal(b, &config); err != nil {
+		return nil, err
+	}
+	if config.Auths == nil && config.CredHelpers == nil && config.CredsStore == "" {
+		// .dockercfg is the auths map itself
+		if err := json.Unmarshal(b, &config.Auths); err != nil {
+			return nil, err
+		}
+	}
+
+	creds := map[string]Credential{}
+	for server, v := range config.Auths {
+		c := Credential{Username: v.Username, Password: v.Password}
+		if v.Auth != "" {
+			decoded, err := base64.StdEncoding.DecodeString(v.Auth)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid auth for registry %s: %s", server, err)
+			}
+			parts := strings.SplitN(string(decoded), ":", 2)
+			if len(parts) == 2 {
+				c = Credential{Username: parts[0], Password: parts[1]}
+			}
+		} else if c.Username == "" && config.CredsStore != "" {
+			// The credentials store keeps the credentials of the listed registries
+			if helped, err := helperCredential(config.CredsStore, server); err == nil {
+				c = helped
+			}
+		}
+		creds[serverDomain(server)] = c
+	}
+
+	for server, helper := range config.CredHelpers {
+		if c, err := helperCredential(helper, server); err == nil {
+			creds[serverDomain(server)] = c
+		}
+	}
+
+	return creds, nil
+}
+
+// UserDockerConfig reads the credentials of the current user docker config, if any
+func UserDockerConfig() (map[string]Credential, error) {
+	dir := os.Getenv("DOCKER_CONFIG")
+	if dir == "" {
+		home := os.Getenv("HOME")
+		if home == "" {
+			home = os.Getenv("USERPROFILE") // windows
+		}
+		dir = filepath.Join(home, ".docker")
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, "config.json"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]Credential{}, nil
+		}
+		return nil, err
+	}
+
+	return DockerConfig(b)
+}
+
+// helperCredential asks a docker credential helper, e.g. docker-credential-gcloud
+func helperCredential(helper string, server string) (Credential, error) {
+	cmd := exec.Command("docker-credential-"+helper, "get")
+	cmd.Stdin = strings.NewReader(server)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	if err := cmd.Run(); err != nil {
+		return Credential{}, err
+	}
+
+	var resp struct {
+		Username string `json:"Username"`
+		Secret   string `json:"Secret"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &resp); err != nil {
+		return Credential{}, err
+	}
+
+	return Credential{Username: resp.Username, Password: resp.Secret}, nil
+}
+
+// serverDomain turns docker config keys like https://index.docker.io/v1/ into domains
+func serverDomain(server string) string {
+	s := strings.TrimPrefix(strings.TrimPrefix(server, "https://"), "http://")
+	if i := strings.Index(s, "/"); i != -1 {
+		s = s[:i]
+	}
+	if s == "index.docker.io" {
+		return DockerHubDomain
+	}
+
+	return s
+}
@@ -0,0 +1,119 @@
+package image
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	// DockerHubDomain is the registry of images without domain, e.g. mongo:3
+	DockerHubDomain  = "docker.io"
+	dockerHubLibrary = "library/"
+)
+
+var (
+	pathComponentRegexp = regexp.MustCompile(`^[a-z0-9]+(?:(?:[._]|__|[-]*)[a-z0-9]+)*$`)
+	tagRegexp           = regexp.MustCompile(`^[\w][\w.-]{0,127}$`)
+	digestRegexp        = regexp.MustCompile(`^[a-z0-9]+(?:[.+_-][a-z0-9]+)*:[a-zA-Z0-9=_-]{32,}$`)
+)
+
+// Reference is a container image reference: [domain/]path[:tag][@digest]
+type Reference struct {
+	// Domain of the registry, including the port if any. Empty for Docker Hub.
+	Domain string
+	Path   string
+	Tag    string
+	Digest string
+}
+
+// Parse splits an image reference into its parts. Unlike splitting on ":" it copes
+// with registries on a port, like localhost:5000/foo, and digests, like foo@sha256:...
+func Parse(s string) (Reference, error) {
+	r := Reference{}
+	rest := s
+
+	if i := strings.Index(rest, "@"); i != -1 {
+		r.Digest = rest[i+1:]
+		rest = rest[:i]
+		if !digestRegexp.MatchString(r.Digest) {
+			return Reference{}, fmt.Errorf("Invalid digest on image reference %s", s)
+		}
+	}
+
+	// A colon after the last slash starts the tag, before it is the registry port
+	if i := strings.LastIndex(rest, ":"); i != -1 && i > strings.LastIndex(rest, "/") {
+		r.Tag = rest[i+1:]
+		rest = rest[:i]
+		if !tagRegexp.MatchString(r.Tag) {
+			return Reference{}, fmt.Errorf("Invalid tag on image reference %s", s)
+		}
+	}
+
+	// The first component is a domain when it looks like a host name
+	if i := strings.Index(rest, "/"); i != -1 {
+		first := rest[:i]
+		if strings.ContainsAny(first, ".:") || first == "localhost" {
+			r.Domain = first
+			rest = rest[i+1:]
+		}
+	}
+
+	r.Path = rest
+	for _, c := range strings.Split(r.Path, "/") {
+		if !pathComponentRegexp.MatchString(c) {
+			return Reference{}, fmt.Errorf("Invalid repository name on image reference %s", s)
+		}
+	}
+
+	return r, nil
+}
+
+// Name is the reference without tag nor digest, e.g. us.gcr.io/project/mercury
+func (r Reference) Name() string {
+	if r.Domain == "" {
+		return r.Path
+	}
+
+	return r.Domain + "/" + r.Path
+}
+
+// Registry is the domain of the registry the image is pulled from
+func (r Reference) Registry() string {
+	if r.Domain == "" {
+		return DockerHubDomain
+	}
+
+	return r.Domain
+}
+
+// Repository is the repository path on the registry, official Docker Hub images live
+// under library/, e.g. library/mongo for mongo:3
+func (r Reference) Repository() string {
+	if r.Registry() == DockerHubDomain && !strings.Contains(r.Path, "/") {
+		return dockerHubLibrary + r.Path
+	}
+
+	return r.Path
+}
+
+// String rebuilds the reference as written
+func (r Reference) String() string {
+	s := r.Name()
+	if r.Tag != "" {
+		s += ":" + r.Tag
+	}
+	if r.Digest != "" {
+		s += "@" + r.Digest
+	}
+
+	return s
+}
+
+// WithTag returns the reference pointing at a tag, dropping any digest
+func (r Reference) WithTag(tag string) Reference {
+	r.Tag = tag
+	r.Digest = ""
+
+	return r
+}
@@ -0,0 +1,65 @@
+package image
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	digest := "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+	tests := []struct {
+		in         string
+		ref        Reference
+		registry   string
+		repository string
+	}{
+		{"mongo", Reference{Path: "mongo"}, DockerHubDomain, "library/mongo"},
+		{"mongo:3", Reference{Path: "mongo", Tag: "3"}, DockerHubDomain, "library/mongo"},
+		{"rakanixu/mercury:v1.2", Reference{Path: "rakanixu/mercury", Tag: "v1.2"}, DockerHubDomain, "rakanixu/mercury"},
+		{"localhost:5000/foo", Reference{Domain: "localhost:5000", Path: "foo"}, "localhost:5000", "foo"},
+		{"localhost/foo:1", Reference{Domain: "localhost", Path: "foo", Tag: "1"}, "localhost", "foo"},
+		{"us.gcr.io/project/mercury:1@" + digest, Reference{Domain: "us.gcr.io", Path: "project/mercury", Tag: "1", Digest: digest}, "us.gcr.io", "project/mercury"},
+		{"foo@" + digest, Reference{Path: "foo", Digest: digest}, DockerHubDomain, "library/foo"},
+	}
+
+	for _, test := range tests {
+		ref, err := Parse(test.in)
+		if err != nil {
+			t.Errorf("%s: %s", test.in, err)
+			continue
+		}
+		if ref != test.ref {
+			t.Errorf("%s: got %+v, want %+v", test.in, ref, test.ref)
+		}
+		if ref.Registry() != test.registry {
+			t.Errorf("%s: got registry %s, want %s", test.in, ref.Registry(), test.registry)
+		}
+		if ref.Repository() != test.repository {
+			t.Errorf("%s: got repository %s, want %s", test.in, ref.Repository(), test.repository)
+		}
+		if ref.String() != test.in {
+			t.Errorf("%s: got string %s", test.in, ref.String())
+		}
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, in := range []string{
+		"",
+		"Mongo",
+		"mongo:",
+		"mongo:-3",
+		"foo//bar",
+		"foo@sha256:short",
+		"localhost:5000/",
+	} {
+		if ref, err := Parse(in); err == nil {
+			t.Errorf("%s: parsed as %+v", in, ref)
+		}
+	}
+}
+
+func TestWithTag(t *testing.T) {
+	ref := Reference{Path: "foo", Tag: "1", Digest: "sha256:abc"}
+	if got := ref.WithTag("2").String(); got != "foo:2" {
+		t.Errorf("got %s, want foo:2", got)
+	}
+}
@@ -0,0 +1,276 @@
+package image
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const dockerHubRegistry = "registry-1.docker.io"
+
+// manifestTypes are the manifest media types accepted when resolving a reference
+var manifestTypes = []string{
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v1+prettyjws",
+}
+
+// ErrNotFound is returned when the registry does not know the tag or digest
+var ErrNotFound = errors.New("manifest unknown")
+
+// Registry resolves image references through the Docker Registry HTTP API v2
+type Registry struct {
+	HTTP *http.Client
+	// Credentials by registry domain, see DockerConfig
+	Credentials map[string]Credential
+	// Insecure registries are reached over plain http. Registries on localhost always are.
+	Insecure []string
+}
+
+// NewRegistry returns a registry client using the given credentials
+func NewRegistry(credentials map[string]Credential, insecure []string) *Registry {
+	return &Registry{
+		HTTP:        &http.Client{Timeout: 30 * time.Second},
+		Credentials: credentials,
+		Insecure:    insecure,
+	}
+}
+
+// Resolve returns the digest of the manifest a reference points at, or ErrNotFound
+func (r *Registry) Resolve(ref Reference) (string, error) {
+	reference := ref.Tag
+	if ref.Digest != "" {
+		reference = ref.Digest
+	}
+	if reference == "" {
+		reference = "latest"
+	}
+
+	u := fmt.Sprintf("%s://%s/v2/%s/manifests/%s", r.scheme(ref), registryHost(ref), ref.Repository(), reference)
+	resp, err := r.do("HEAD", u, ref)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+
+	if digest := resp.Header.Get("Docker-Content-Digest"); digest != "" {
+		return digest, nil
+	}
+
+	// Some registries only send the digest on GET, hash the manifest then
+	resp, err = r.do("GET", u, ref)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if digest := resp.Header.Get("Docker-Content-Digest"); digest != "" {
+		return digest, nil
+	}
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
+
+// do sends a manifest request, authenticating when the registry asks for it
+func (r *Registry) do(method string, u string, ref Reference) (*http.Response, error) {
+	resp, err := r.request(method, u, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+
+		auth, err := r.authorization(challenge, ref)
+		if err != nil {
+			return nil, err
+		}
+		if resp, err = r.request(method, u, auth); err != nil {
+			return nil, err
+		}
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, ErrNotFound
+	default:
+		resp.Body.Close()
+		return nil, fmt.Errorf("Registry %s answered %s for %s", registryHost(ref), resp.Status, ref)
+	}
+}
+
+func (r *Registry) request(method string, u string, auth string) (*http.Response, error) {
+	req, err := http.NewRequest(method, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", strings.Join(manifestTypes, ", "))
+	if auth != "" {
+		req.Header.Set("Authorization", auth)
+	}
+
+	return r.HTTP.Do(req)
+}
+
+// authorization answers a WWW-Authenticate challenge, returning the Authorization header
+func (r *Registry) authorization(challenge string, ref Reference) (string, error) {
+	cred, hasCred := r.credential(ref)
+	scheme, params := parseChallenge(challenge)
+
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if !hasCred {
+			return "", fmt.Errorf("Registry %s requires credentials", registryHost(ref))
+		}
+		return "Basic " + cred.basic(), nil
+	case "bearer":
+		return r.token(params, ref, cred, hasCred)
+	}
+
+	return "", fmt.Errorf("Unsupported authentication challenge from registry %s: %s", registryHost(ref), challenge)
+}
+
+// token gets a bearer token from the registry auth server
+func (r *Registry) token(params map[string]string, ref Reference, cred Credential, hasCred bool) (string, error) {
+	u, err := url.Parse(params["realm"])
+	if err != nil || params["realm"] == "" {
+		return "", fmt.Errorf("Invalid token realm from registry %s", registryHost(ref))
+	}
+	q := u.Query()
+	if params["service"] != "" {
+		q.Set("service", params["service"])
+	}
+	scope := params["scope"]
+	if scope == "" {
+		scope = fmt.Sprintf("repository:%s:pull", ref.Repository())
+	}
+	q.Set("scope", scope)
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	if hasCred {
+		req.Header.Set("Authorization", "Basic "+cred.basic())
+	}
+
+	resp, err := r.HTTP.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Could not authenticate against registry %s: %s", registryHost(ref), resp.Status)
+	}
+
+	var t struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&t); err != nil {
+		return "", err
+	}
+	if t.Token == "" {
+		t.Token = t.AccessToken
+	}
+
+	return "Bearer " + t.Token, nil
+}
+
+func (r *Registry) credential(ref Reference) (Credential, bool) {
+	keys := []string{ref.Registry()}
+	if ref.Registry() == DockerHubDomain {
+		keys = append(keys, "index.docker.io", dockerHubRegistry)
+	}
+	for _, k := range keys {
+		if c, ok := r.Credentials[k]; ok {
+			return c, true
+		}
+	}
+
+	return Credential{}, false
+}
+
+func (r *Registry) scheme(ref Reference) string {
+	for _, v := range r.Insecure {
+		if v == ref.Registry() {
+			return "http"
+		}
+	}
+
+	host := ref.Registry()
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	if host == "localhost" || host == "127.0.0.1" || host == "::1" {
+		return "http"
+	}
+
+	return "https"
+}
+
+func registryHost(ref Reference) string {
+	if ref.Registry() == DockerHubDomain {
+		return dockerHubRegistry
+	}
+
+	return ref.Registry()
+}
+
+// parseChallenge splits a WWW-Authenticate header like
+// Bearer realm="https://auth.docker.io/token",service="registry.docker.io"
+func parseChallenge(challenge string) (string, map[string]string) {
+	params := map[string]string{}
+
+	parts := strings.SplitN(strings.TrimSpace(challenge), " ", 2)
+	if len(parts) < 2 {
+		return parts[0], params
+	}
+
+	rest := parts[1]
+	for rest != "" {
+		eq := strings.Index(rest, "=")
+		if eq == -1 {
+			break
+		}
+		key := strings.ToLower(strings.TrimSpace(rest[:eq]))
+		rest = rest[eq+1:]
+
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.Index(rest[1:], `"`)
+			if end == -1 {
+				value, rest = rest[1:], ""
+			} else {
+				value, rest = rest[1:end+1], rest[end+2:]
+			}
+		} else if comma := strings.Index(rest, ","); comma != -1 {
+			value, rest = rest[:comma], rest[comma:]
+		} else {
+			value, rest = rest, ""
+		}
+		params[key] = value
+		rest = strings.TrimLeft(rest, ", ")
+	}
+
+	return parts[0], params
+}
@@ -0,0 +1,213 @@
+package image
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testDigest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+// testRegistry serves the manifest of foo/bar:1.0. auth is the Authorization header
+// manifest requests must carry, challenge the WWW-Authenticate header sent otherwise.
+type testRegistry struct {
+	auth      string
+	challenge string
+	// headDigest sends the digest on HEAD, otherwise only GET answers, without it
+	headDigest bool
+	manifest   string
+	// requests are the method and path of every request received
+	requests []string
+}
+
+func (t *testRegistry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	t.requests = append(t.requests, r.Method+" "+r.URL.Path)
+
+	if t.auth != "" && r.Header.Get("Authorization") != t.auth {
+		w.Header().Set("WWW-Authenticate", t.challenge)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if r.URL.Path != "/v2/foo/bar/manifests/1.0" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if !strings.Contains(r.Header.Get("Accept"), "application/vnd.docker.distribution.manifest.v2+json") {
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	if t.headDigest {
+		w.Header().Set("Docker-Content-Digest", testDigest)
+	}
+	if r.Method == "GET" {
+		fmt.Fprint(w, t.manifest)
+	}
+}
+
+func testReference(t *testing.T, server *httptest.Server, path string) Reference {
+	ref, err := Parse(strings.TrimPrefix(server.URL, "http://") + "/" + path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return ref
+}
+
+func TestResolveHead(t *testing.T) {
+	registry := &testRegistry{headDigest: true}
+	server := httptest.NewServer(registry)
+	defer server.Close()
+
+	digest, err := NewRegistry(nil, nil).Resolve(testReference(t, server, "foo/bar:1.0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if digest != testDigest {
+		t.Errorf("got digest %s, want %s", digest, testDigest)
+	}
+	if len(registry.requests) != 1 || registry.requests[0] != "HEAD /v2/foo/bar/manifests/1.0" {
+		t.Errorf("got requests %v, want a single HEAD", registry.requests)
+	}
+}
+
+func TestResolveGetFallback(t *testing.T) {
+	registry := &testRegistry{manifest: `{"schemaVersion":2}`}
+	server := httptest.NewServer(registry)
+	defer server.Close()
+
+	digest, err := NewRegistry(nil, nil).Resolve(testReference(t, server, "foo/bar:1.0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256([]byte(registry.manifest))
+	if want := "sha256:" + hex.EncodeToString(sum[:]); digest != want {
+		t.Errorf("got digest %s, want %s", digest, want)
+	}
+	if len(registry.requests) != 2 || registry.requests[1] != "GET /v2/foo/bar/manifests/1.0" {
+		t.Errorf("got requests %v, want HEAD then GET", registry.requests)
+	}
+}
+
+func TestResolveNotFound(t *testing.T) {
+	server := httptest.NewServer(&testRegistry{headDigest: true})
+	defer server.Close()
+
+	for _, path := range []string{"foo/bar:2.0", "foo/baz:1.0"} {
+		if _, err := NewRegistry(nil, nil).Resolve(testReference(t, server, path)); err != ErrNotFound {
+			t.Errorf("%s: got error %v, want ErrNotFound", path, err)
+		}
+	}
+}
+
+func TestResolveBasic(t *testing.T) {
+	cred := Credential{Username: "user", Password: "secret"}
+	registry := &testRegistry{
+		auth:       "Basic " + cred.basic(),
+		challenge:  `Basic realm="registry"`,
+		headDigest: true,
+	}
+	server := httptest.NewServer(registry)
+	defer server.Close()
+	ref := testReference(t, server, "foo/bar:1.0")
+
+	if _, err := NewRegistry(nil, nil).Resolve(ref); err == nil {
+		t.Error("resolved without credentials")
+	}
+
+	creds := map[string]Credential{ref.Registry(): cred}
+	digest, err := NewRegistry(creds, nil).Resolve(ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if digest != testDigest {
+		t.Errorf("got digest %s, want %s", digest, testDigest)
+	}
+}
+
+func TestResolveBearer(t *testing.T) {
+	cred := Credential{Username: "user", Password: "secret"}
+
+	var tokenQuery string
+	auth := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokenQuery = r.URL.RawQuery
+		if r.Header.Get("Authorization") != "Basic "+cred.basic() {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"token":"t0k3n"}`)
+	}))
+	defer auth.Close()
+
+	registry := &testRegistry{
+		auth:       "Bearer t0k3n",
+		challenge:  fmt.Sprintf(`Bearer realm="%s/token",service="test-registry"`, auth.URL),
+		headDigest: true,
+	}
+	server := httptest.NewServer(registry)
+	defer server.Close()
+	ref := testReference(t, server, "foo/bar:1.0")
+
+	if _, err := NewRegistry(nil, nil).Resolve(ref); err == nil {
+		t.Error("resolved with a token denied by the auth server")
+	}
+
+	creds := map[string]Credential{ref.Registry(): cred}
+	digest, err := NewRegistry(creds, nil).Resolve(ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if digest != testDigest {
+		t.Errorf("got digest %s, want %s", digest, testDigest)
+	}
+	if want := "scope=repository%3Afoo%2Fbar%3Apull&service=test-registry"; tokenQuery != want {
+		t.Errorf("got token query %s, want %s", tokenQuery, want)
+	}
+}
+
+func TestParseChallenge(t *testing.T) {
+	tests := []struct {
+		challenge string
+		scheme    string
+		params    map[string]string
+	}{
+		{
+			challenge: `Bearer realm="https://auth.docker.io/token",service="registry.docker.io",scope="repository:library/mongo:pull"`,
+			scheme:    "Bearer",
+			params: map[string]string{
+				"realm":   "https://auth.docker.io/token",
+				"service": "registry.docker.io",
+				"scope":   "repository:library/mongo:pull",
+			},
+		},
+		{
+			challenge: `Basic realm="Registry Realm"`,
+			scheme:    "Basic",
+			params:    map[string]string{"realm": "Registry Realm"},
+		},
+		{
+			challenge: `Bearer Realm=https://auth.example.com/token, service=example`,
+			scheme:    "Bearer",
+			params:    map[string]string{"realm": "https://auth.example.com/token", "service": "example"},
+		},
+		{
+			challenge: "Basic",
+			scheme:    "Basic",
+			params:    map[string]string{},
+		},
+	}
+
+	for _, test := range tests {
+		scheme, params := parseChallenge(test.challenge)
+		if scheme != test.scheme {
+			t.Errorf("%s: got scheme %s, want %s", test.challenge, scheme, test.scheme)
+		}
+		if fmt.Sprint(params) != fmt.Sprint(test.params) {
+			t.Errorf("%s: got params %v, want %v", test.challenge, params, test.params)
+		}
+	}
+}
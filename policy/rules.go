@@ -0,0 +1,123 @@
+package policy
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/Rakanixu/k8-cid/utils"
+	"github.com/ghodss/yaml"
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/checker/decls"
+)
+
+const (
+	// SeverityWarn violations are reported
+	SeverityWarn = "warn"
+	// SeverityFail violations stop the environment from being created
+	SeverityFail = "fail"
+)
+
+// Violation of a rule by an object
+type Violation struct {
+	Rule     string
+	Severity string
+	Message  string
+}
+
+// Engine evaluates compiled rules against objects
+type Engine struct {
+	rules    []utils.Rule
+	programs []cel.Program
+}
+
+// ReadRules reads a YAML or JSON list of rules
+func ReadRules(path string) ([]utils.Rule, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var rules []utils.Rule
+	if err := yaml.Unmarshal(b, &rules); err != nil {
+		return nil, fmt.Errorf("%s: %s", path, err)
+	}
+
+	return rules, nil
+}
+
+// NewEngine compiles the rules, failing on the first invalid one
+func NewEngine(rules []utils.Rule) (*Engine, error) {
+	env, err := cel.NewEnv(cel.Declarations(
+		decls.NewVar("object", decls.Dyn),
+		decls.NewVar("component", decls.String),
+		decls.NewVar("repo", decls.String),
+		// namespace is a reserved word in CEL
+		decls.NewVar("environment", decls.String),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	e := &Engine{}
+	for _, r := range rules {
+		switch r.Severity {
+		case "":
+			r.Severity = SeverityWarn
+		case SeverityWarn, SeverityFail:
+		default:
+			return nil, fmt.Errorf("Rule %s: unknown severity %s", r.Name, r.Severity)
+		}
+		if r.Expression == "" {
+			return nil, fmt.Errorf("Rule %s: expression is required", r.Name)
+		}
+
+		ast, iss := env.Compile(r.Expression)
+		if iss != nil && iss.Err() != nil {
+			return nil, fmt.Errorf("Rule %s: %s", r.Name, iss.Err())
+		}
+		prg, err := env.Program(ast)
+		if err != nil {
+			return nil, fmt.Errorf("Rule %s: %s", r.Name, err)
+		}
+
+		e.rules = append(e.rules, r)
+		e.programs = append(e.programs, prg)
+	}
+
+	return e, nil
+}
+
+// Evaluate returns the violations of the rules applying to the object. Rules failing
+// to evaluate, e.g. on a missing field not guarded by has(), count as violated.
+func (e *Engine) Evaluate(obj map[string]interface{}, component string, repo string, namespace string) []Violation {
+	var violations []Violation
+
+	kind, _ := obj["kind"].(string)
+	vars := map[string]interface{}{
+		"object":      obj,
+		"component":   component,
+		"repo":        repo,
+		"environment": namespace,
+	}
+	for k, r := range e.rules {
+		if len(r.Kinds) > 0 && utils.Find(r.Kinds, kind) == -1 {
+			continue
+		}
+
+		message := r.Message
+		if message == "" {
+			message = r.Expression
+		}
+
+		out, _, err := e.programs[k].Eval(vars)
+		if err != nil {
+			violations = append(violations, Violation{Rule: r.Name, Severity: r.Severity, Message: fmt.Sprintf("%s (%s)", message, err)})
+			continue
+		}
+		if ok, isBool := out.Value().(bool); !isBool || !ok {
+			violations = append(violations, Violation{Rule: r.Name, Severity: r.Severity, Message: message})
+		}
+	}
+
+	return violations
+}
@@ -0,0 +1,104 @@
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/Rakanixu/k8-cid/utils"
+)
+
+var testRules = []utils.Rule{
+	{
+		Name:       "limits",
+		Kinds:      []string{"Deployment"},
+		Expression: `object.spec.template.spec.containers.all(c, has(c.resources.limits))`,
+		Message:    "containers must set resource limits",
+		Severity:   SeverityFail,
+	},
+	{
+		Name:       "latest",
+		Kinds:      []string{"Deployment"},
+		Expression: `repo == "public" || object.spec.template.spec.containers.all(c, !c.image.endsWith(":latest"))`,
+	},
+	{
+		Name:       "readiness",
+		Kinds:      []string{"Deployment"},
+		Expression: `object.spec.template.spec.containers.all(c, has(c.readinessProbe))`,
+		Message:    "containers must have readiness probes",
+	},
+	{
+		Name:       "namespace",
+		Expression: `!has(object.metadata.namespace) || object.metadata.namespace == environment`,
+	},
+}
+
+func testDeployment(t *testing.T, containers string) map[string]interface{} {
+	obj := map[string]interface{}{}
+	s := fmt.Sprintf(`{"kind": "Deployment", "metadata": {"name": "mercury"}, "spec": {"template": {"spec": {"containers": %s}}}}`, containers)
+	if err := json.Unmarshal([]byte(s), &obj); err != nil {
+		t.Fatal(err)
+	}
+
+	return obj
+}
+
+func TestEvaluate(t *testing.T) {
+	e, err := NewEngine(testRules)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ready := `{"name": "a", "image": "us.gcr.io/p/mercury:1", "resources": {"limits": {"cpu": "1"}}, "readinessProbe": {}}`
+	latest := `{"name": "b", "image": "mongo:latest", "resources": {}, "readinessProbe": {}}`
+
+	tests := []struct {
+		name       string
+		obj        map[string]interface{}
+		repo       string
+		violations []string
+	}{
+		{"compliant", testDeployment(t, "["+ready+"]"), "juno", nil},
+		{"latest image", testDeployment(t, "["+ready+","+latest+"]"), "juno", []string{"limits/fail", "latest/warn"}},
+		{"latest image of the public repo", testDeployment(t, "["+latest+"]"), "public", []string{"limits/fail"}},
+		{"no probes", testDeployment(t, `[{"name": "c", "image": "c:1", "resources": {"limits": {}}}]`), "juno", []string{"readiness/warn"}},
+		{"other kind", map[string]interface{}{"kind": "Service", "metadata": map[string]interface{}{"namespace": "juno"}}, "juno", nil},
+		{"other namespace", map[string]interface{}{"kind": "Service", "metadata": map[string]interface{}{"namespace": "default"}}, "juno", []string{"namespace/warn"}},
+	}
+
+	for _, test := range tests {
+		var got []string
+		for _, v := range e.Evaluate(test.obj, "mercury", test.repo, "juno") {
+			got = append(got, v.Rule+"/"+v.Severity)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(test.violations) {
+			t.Errorf("%s: got violations %v, want %v", test.name, got, test.violations)
+		}
+	}
+}
+
+func TestEvaluateError(t *testing.T) {
+	e, err := NewEngine([]utils.Rule{{Name: "replicas", Expression: `object.spec.replicas > 1.0`}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// spec.replicas is missing and not guarded by has()
+	violations := e.Evaluate(map[string]interface{}{"spec": map[string]interface{}{}}, "", "", "")
+	if len(violations) != 1 || violations[0].Severity != SeverityWarn {
+		t.Errorf("got violations %v, want one warning", violations)
+	}
+}
+
+func TestNewEngineInvalid(t *testing.T) {
+	for _, r := range []utils.Rule{
+		{Name: "empty"},
+		{Name: "syntax", Expression: `object.spec.`},
+		{Name: "undeclared", Expression: `deployment.spec.replicas > 1`},
+		{Name: "severity", Expression: `true`, Severity: "error"},
+	} {
+		if _, err := NewEngine([]utils.Rule{r}); err == nil {
+			t.Errorf("%s: compiled", r.Name)
+		}
+	}
+}
@@ -0,0 +1,234 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+)
+
+const (
+	refPrefix   = "#/definitions/"
+	quantityRef = "io.k8s.apimachinery.pkg.api.resource.Quantity"
+	intOrString = "int-or-string"
+)
+
+// Schema is the Kubernetes OpenAPI v2 spec, as served by the API server on /openapi/v2
+type Schema struct {
+	Definitions map[string]*Definition `json:"definitions"`
+	// kinds maps group/version/kind to definition names
+	kinds map[string]string
+}
+
+// Definition is the subset of an OpenAPI schema objects are validated against
+type Definition struct {
+	Type                 string                 `json:"type"`
+	Format               string                 `json:"format"`
+	Ref                  string                 `json:"$ref"`
+	Properties           map[string]*Definition `json:"properties"`
+	Items                *Definition            `json:"items"`
+	Required             []string               `json:"required"`
+	AdditionalProperties json.RawMessage        `json:"additionalProperties"`
+	GroupVersionKinds    []GroupVersionKind     `json:"x-kubernetes-group-version-kind"`
+}
+
+// GroupVersionKind of the objects a definition describes
+type GroupVersionKind struct {
+	Group   string `json:"group"`
+	Version string `json:"version"`
+	Kind    string `json:"kind"`
+}
+
+// FieldError is a field of an object not matching the schema
+type FieldError struct {
+	// Path is the JSON path of the field, e.g. .spec.template.spec.containers[0].image
+	Path    string
+	Message string
+}
+
+func (e FieldError) String() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// Parse parses an OpenAPI v2 spec
+func Parse(b []byte) (*Schema, error) {
+	s := &Schema{}
+	if err := json.Unmarshal(b, s); err != nil {
+		return nil, err
+	}
+	if len(s.Definitions) == 0 {
+		return nil, fmt.Errorf("OpenAPI spec has no definitions")
+	}
+
+	s.kinds = map[string]string{}
+	for name, def := range s.Definitions {
+		for _, v := range def.GroupVersionKinds {
+			s.kinds[gvkKey(v.Group, v.Version, v.Kind)] = name
+		}
+	}
+
+	return s, nil
+}
+
+// Read parses an offline copy of the OpenAPI v2 spec, e.g. saved with
+// kubectl get --raw /openapi/v2
+func Read(path string) (*Schema, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := Parse(b)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", path, err)
+	}
+
+	return s, nil
+}
+
+// Validate checks an object, decoded from JSON, against the definition of its apiVersion and kind
+func (s *Schema) Validate(obj map[string]interface{}) ([]FieldError, error) {
+	apiVersion, _ := obj["apiVersion"].(string)
+	kind, _ := obj["kind"].(string)
+	group, version := "", apiVersion
+	if i := strings.Index(apiVersion, "/"); i != -1 {
+		group, version = apiVersion[:i], apiVersion[i+1:]
+	}
+
+	name, ok := s.kinds[gvkKey(group, version, kind)]
+	if !ok {
+		return nil, fmt.Errorf("No schema for kind %s of apiVersion %s", kind, apiVersion)
+	}
+
+	var errs []FieldError
+	s.validate(s.Definitions[name], name, obj, "", &errs)
+
+	return errs, nil
+}
+
+func (s *Schema) validate(def *Definition, name string, v interface{}, path string, errs *[]FieldError) {
+	if v == nil || def == nil {
+		return
+	}
+	if def.Ref != "" {
+		name = strings.TrimPrefix(def.Ref, refPrefix)
+		s.validate(s.Definitions[name], name, v, path, errs)
+		return
+	}
+
+	fail := func(format string, a ...interface{}) {
+		*errs = append(*errs, FieldError{Path: path, Message: fmt.Sprintf(format, a...)})
+	}
+
+	switch def.Type {
+	case "object", "":
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			if def.Type == "object" {
+				fail("expected object, got %s", typeOf(v))
+			}
+			return
+		}
+		if len(def.Properties) == 0 {
+			if additional := def.additional(); additional != nil {
+				for _, k := range sortedKeys(m) {
+					s.validate(additional, "", m[k], path+"."+k, errs)
+				}
+			}
+			return
+		}
+		for _, r := range def.Required {
+			if _, ok := m[r]; !ok {
+				*errs = append(*errs, FieldError{Path: path + "." + r, Message: "missing required field"})
+			}
+		}
+		for _, k := range sortedKeys(m) {
+			p, ok := def.Properties[k]
+			if !ok {
+				*errs = append(*errs, FieldError{Path: path + "." + k, Message: "unknown field"})
+				continue
+			}
+			s.validate(p, "", m[k], path+"."+k, errs)
+		}
+	case "array":
+		items, ok := v.([]interface{})
+		if !ok {
+			fail("expected array, got %s", typeOf(v))
+			return
+		}
+		for i, item := range items {
+			s.validate(def.Items, "", item, fmt.Sprintf("%s[%d]", path, i), errs)
+		}
+	case "string":
+		if _, ok := v.(string); ok {
+			return
+		}
+		// Quantities and int-or-strings, such as ports, may be numbers
+		if _, ok := v.(float64); ok && (name == quantityRef || def.Format == intOrString) {
+			return
+		}
+		fail("expected string, got %s", typeOf(v))
+	case "integer":
+		if f, ok := v.(float64); !ok || f != float64(int64(f)) {
+			fail("expected integer, got %s", typeOf(v))
+		}
+	case "number":
+		if _, ok := v.(float64); !ok {
+			fail("expected number, got %s", typeOf(v))
+		}
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			fail("expected boolean, got %s", typeOf(v))
+		}
+	}
+}
+
+// additional returns the schema of the values of a map, nil when any value is allowed
+func (def *Definition) additional() *Definition {
+	if len(def.AdditionalProperties) == 0 {
+		return nil
+	}
+
+	a := &Definition{}
+	if err := json.Unmarshal(def.AdditionalProperties, a); err != nil {
+		// additionalProperties: true
+		return nil
+	}
+
+	return a
+}
+
+func gvkKey(group string, version string, kind string) string {
+	return group + "/" + version + "/" + kind
+}
+
+func typeOf(v interface{}) string {
+	switch t := v.(type) {
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64:
+		if t == float64(int64(t)) {
+			return "integer"
+		}
+		return "number"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+
+	return fmt.Sprintf("%T", v)
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
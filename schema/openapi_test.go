@@ -0,0 +1,128 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+const testOpenAPI = `{
+  "definitions": {
+    "io.k8s.api.core.v1.Service": {
+      "type": "object",
+      "required": ["spec"],
+      "properties": {
+        "apiVersion": {"type": "string"},
+        "kind": {"type": "string"},
+        "metadata": {"$ref": "#/definitions/io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta"},
+        "spec": {"$ref": "#/definitions/io.k8s.api.core.v1.ServiceSpec"}
+      },
+      "x-kubernetes-group-version-kind": [{"group": "", "version": "v1", "kind": "Service"}]
+    },
+    "io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta": {
+      "type": "object",
+      "properties": {
+        "name": {"type": "string"},
+        "labels": {"type": "object", "additionalProperties": {"type": "string"}}
+      }
+    },
+    "io.k8s.api.core.v1.ServiceSpec": {
+      "type": "object",
+      "properties": {
+        "ports": {"type": "array", "items": {"$ref": "#/definitions/io.k8s.api.core.v1.ServicePort"}},
+        "sessionAffinity": {"type": "string"},
+        "publishNotReadyAddresses": {"type": "boolean"}
+      }
+    },
+    "io.k8s.api.core.v1.ServicePort": {
+      "type": "object",
+      "required": ["port"],
+      "properties": {
+        "port": {"type": "integer", "format": "int32"},
+        "targetPort": {"type": "string", "format": "int-or-string"}
+      }
+    }
+  }
+}`
+
+func testObject(t *testing.T, s string) map[string]interface{} {
+	obj := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(s), &obj); err != nil {
+		t.Fatal(err)
+	}
+
+	return obj
+}
+
+func TestValidate(t *testing.T) {
+	s, err := Parse([]byte(testOpenAPI))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		obj  string
+		errs []string
+	}{
+		{
+			obj: `{"apiVersion": "v1", "kind": "Service", "metadata": {"name": "mercury", "labels": {"app": "mercury"}},
+				"spec": {"ports": [{"port": 80, "targetPort": 8080}, {"port": 81, "targetPort": "http"}]}}`,
+		},
+		{
+			obj: `{"apiVersion": "v1", "kind": "Service", "metadata": {"name": "mercury", "labels": {"app": 1}},
+				"spec": {"ports": [{"port": "80", "tragetPort": 8080}, {"port": 80.5}], "publishNotReadyAddresses": "yes"}}`,
+			errs: []string{
+				".metadata.labels.app: expected string, got integer",
+				".spec.ports[0].port: expected integer, got string",
+				".spec.ports[0].tragetPort: unknown field",
+				".spec.ports[1].port: expected integer, got number",
+				".spec.publishNotReadyAddresses: expected boolean, got string",
+			},
+		},
+		{
+			obj:  `{"apiVersion": "v1", "kind": "Service", "spec": {"ports": {"port": 80}}, "status": {}}`,
+			errs: []string{".spec.ports: expected array, got object", ".status: unknown field"},
+		},
+		{
+			obj:  `{"apiVersion": "v1", "kind": "Service", "metadata": {"name": "mercury"}}`,
+			errs: []string{".spec: missing required field"},
+		},
+		{
+			obj:  `{"apiVersion": "v1", "kind": "Service", "spec": {"ports": [{}]}}`,
+			errs: []string{".spec.ports[0].port: missing required field"},
+		},
+	}
+
+	for _, test := range tests {
+		errs, err := s.Validate(testObject(t, test.obj))
+		if err != nil {
+			t.Errorf("%s: %s", test.obj, err)
+			continue
+		}
+		if got, want := fmt.Sprint(errs), fmt.Sprint(test.errs); got != want {
+			t.Errorf("%s: got %s, want %s", test.obj, got, want)
+		}
+	}
+}
+
+func TestValidateUnknownKind(t *testing.T) {
+	s, err := Parse([]byte(testOpenAPI))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, obj := range []string{
+		`{"apiVersion": "v1", "kind": "Pod"}`,
+		`{"apiVersion": "apps/v1", "kind": "Service"}`,
+	} {
+		if _, err := s.Validate(testObject(t, obj)); err == nil {
+			t.Errorf("%s: validated against no schema", obj)
+		}
+	}
+}
+
+func TestParseNoDefinitions(t *testing.T) {
+	if _, err := Parse([]byte(`{"swagger": "2.0"}`)); err == nil {
+		t.Error("parsed a spec without definitions")
+	}
+}
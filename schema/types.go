@@ -0,0 +1,168 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// FromTypes builds a schema from Go API types, such as the ones of k8s.io/api, by the
+// JSON encoding of their fields. It knows neither required fields nor formats, and
+// types decoding themselves, like quantities, accept any value.
+func FromTypes(kinds map[GroupVersionKind]interface{}) *Schema {
+	s := &Schema{
+		Definitions: map[string]*Definition{},
+		kinds:       map[string]string{},
+	}
+	for gvk, obj := range kinds {
+		def := s.definition(reflect.TypeOf(obj))
+		s.kinds[gvkKey(gvk.Group, gvk.Version, gvk.Kind)] = strings.TrimPrefix(def.Ref, refPrefix)
+	}
+
+	return s
+}
+
+// definition returns the definition of a type, named struct types are added to the
+// schema definitions and referenced
+func (s *Schema) definition(t reflect.Type) *Definition {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if decodesItself(t) {
+		return &Definition{}
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if t.Name() == "" {
+			return s.object(t)
+		}
+		name := t.PkgPath() + "." + t.Name()
+		if _, ok := s.Definitions[name]; !ok {
+			// Added before walking the fields, types may refer to themselves
+			s.Definitions[name] = &Definition{}
+			*s.Definitions[name] = *s.object(t)
+		}
+		return &Definition{Ref: refPrefix + name}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Definition{Type: "string", Format: "byte"}
+		}
+		return &Definition{Type: "array", Items: s.definition(t.Elem())}
+	case reflect.Map:
+		additional, _ := json.Marshal(s.definition(t.Elem()))
+		return &Definition{Type: "object", AdditionalProperties: additional}
+	case reflect.String:
+		return &Definition{Type: "string"}
+	case reflect.Bool:
+		return &Definition{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Definition{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Definition{Type: "number"}
+	}
+
+	return &Definition{}
+}
+
+func (s *Schema) object(t reflect.Type) *Definition {
+	def := &Definition{Type: "object", Properties: map[string]*Definition{}}
+	for name, ft := range jsonFields(t) {
+		def.Properties[name] = s.definition(ft)
+	}
+
+	return def
+}
+
+// UnknownFields returns the JSON paths of the fields of v, decoded from JSON, that
+// type t does not have, e.g. .spec.template.spec.containers[0].ports[0].contianerPort
+func UnknownFields(t reflect.Type, v interface{}) []string {
+	return unknownFields(t, v, "")
+}
+
+func unknownFields(t reflect.Type, v interface{}, path string) []string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	// Types decoding themselves, e.g. quantities, are not walked into
+	if decodesItself(t) {
+		return nil
+	}
+
+	var unknown []string
+	switch t.Kind() {
+	case reflect.Struct:
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		fields := jsonFields(t)
+		for _, k := range sortedKeys(m) {
+			ft, ok := fields[k]
+			if !ok {
+				unknown = append(unknown, path+"."+k)
+				continue
+			}
+			unknown = append(unknown, unknownFields(ft, m[k], path+"."+k)...)
+		}
+	case reflect.Slice, reflect.Array:
+		s, ok := v.([]interface{})
+		if !ok {
+			return nil
+		}
+		for i, e := range s {
+			unknown = append(unknown, unknownFields(t.Elem(), e, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	case reflect.Map:
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		for _, k := range sortedKeys(m) {
+			unknown = append(unknown, unknownFields(t.Elem(), m[k], path+"."+k)...)
+		}
+	}
+
+	return unknown
+}
+
+func decodesItself(t reflect.Type) bool {
+	return t.Implements(unmarshalerType) || reflect.PtrTo(t).Implements(unmarshalerType)
+}
+
+// jsonFields returns the types of the fields of a struct by JSON name, including
+// the fields of embedded structs such as TypeMeta
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+
+		ft := f.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			for k, v := range jsonFields(ft) {
+				fields[k] = v
+			}
+			continue
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = f.Type
+	}
+
+	return fields
+}
@@ -0,0 +1,117 @@
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+type testMeta struct {
+	Kind       string `json:"kind,omitempty"`
+	APIVersion string `json:"apiVersion,omitempty"`
+}
+
+// testQuantity decodes itself from either a number or a string
+type testQuantity struct {
+	value string
+}
+
+func (q *testQuantity) UnmarshalJSON(b []byte) error {
+	q.value = string(b)
+
+	return nil
+}
+
+type testContainer struct {
+	Name   string                  `json:"name"`
+	Ports  []int32                 `json:"ports,omitempty"`
+	Limits map[string]testQuantity `json:"limits,omitempty"`
+	Debug  bool                    `json:"debug,omitempty"`
+	Data   []byte                  `json:"data,omitempty"`
+	secret string
+}
+
+type testSpec struct {
+	Replicas   *int32          `json:"replicas,omitempty"`
+	Containers []testContainer `json:"containers"`
+	Labels     map[string]string
+	Ignored    string `json:"-"`
+}
+
+type testDeployment struct {
+	testMeta `json:",inline"`
+	Spec     testSpec `json:"spec"`
+	// Next refers to its own type
+	Next *testDeployment `json:"next,omitempty"`
+}
+
+func TestUnknownFields(t *testing.T) {
+	obj := testObject(t, `{
+		"apiVersion": "v1", "kind": "Deployment", "replicas": 2,
+		"spec": {
+			"replicas": 2, "Labels": {"app": "mercury"}, "Ignored": "x",
+			"containers": [
+				{"name": "a", "limits": {"memory": {"any": "value"}}, "secret": "s"},
+				{"name": "b", "prots": [80]}
+			]
+		},
+		"next": {"spec": {"replica": 1}}
+	}`)
+
+	want := []string{
+		".next.spec.replica",
+		".replicas",
+		".spec.Ignored",
+		".spec.containers[0].secret",
+		".spec.containers[1].prots",
+	}
+	if got := UnknownFields(reflect.TypeOf(testDeployment{}), obj); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestFromTypes(t *testing.T) {
+	s := FromTypes(map[GroupVersionKind]interface{}{
+		{Group: "apps", Version: "v1", Kind: "Deployment"}: &testDeployment{},
+	})
+
+	tests := []struct {
+		obj  string
+		errs []string
+	}{
+		{
+			obj: `{"apiVersion": "apps/v1", "kind": "Deployment", "spec": {"replicas": 2, "Labels": {"app": "mercury"},
+				"containers": [{"name": "a", "ports": [80], "limits": {"cpu": 1, "memory": "1Gi"}, "data": "Zm9v"}]},
+				"next": {"spec": {"containers": []}}}`,
+		},
+		{
+			obj: `{"apiVersion": "apps/v1", "kind": "Deployment", "spec": {"replicas": "2", "Labels": {"app": true},
+				"containers": [{"name": 1, "ports": ["80"], "debug": "true", "prots": [80]}]},
+				"next": {"spec": {"replica": 1}}}`,
+			errs: []string{
+				".next.spec.replica: unknown field",
+				".spec.Labels.app: expected string, got boolean",
+				".spec.containers[0].debug: expected boolean, got string",
+				".spec.containers[0].name: expected string, got integer",
+				".spec.containers[0].ports[0]: expected integer, got string",
+				".spec.containers[0].prots: unknown field",
+				".spec.replicas: expected integer, got string",
+			},
+		},
+	}
+
+	for i, test := range tests {
+		errs, err := s.Validate(testObject(t, test.obj))
+		if err != nil {
+			t.Errorf("%d: %s", i, err)
+			continue
+		}
+		if got, want := fmt.Sprint(errs), fmt.Sprint(test.errs); got != want {
+			t.Errorf("%d: got %s\nwant %s", i, got, want)
+		}
+	}
+
+	if _, err := s.Validate(testObject(t, `{"apiVersion": "v1", "kind": "Deployment"}`)); err == nil {
+		t.Error("validated a kind of another group")
+	}
+}
@@ -0,0 +1,311 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+)
+
+// ProjectFile declares the repos, components and defaults of a project. It is
+// versioned next to the manifests and read from the working directory.
+const ProjectFile = "k8cid.yaml"
+
+// DefaultManifests is the directory component manifests are read from
+const DefaultManifests = "config"
+
+// Project is the project file. Its settings are the defaults the shared and user
+// settings override, and its repos are overridden repo by repo by the shared and
+// user repositories.
+type Project struct {
+	// Repos maps every repo to its components
+	Repos map[string][]string `json:"repos"`
+	// Manifests is the directory of the component manifests, config by default
+	Manifests string `json:"manifests"`
+	// Defaults are settings, as in settings.json
+	Defaults map[string]interface{} `json:"defaults"`
+	// Components options by component
+	Components map[string]ComponentOptions `json:"components"`
+}
+
+// ComponentOptions are shorthands for the settings of a single component
+type ComponentOptions struct {
+	Replicas *int32 `json:"replicas"`
+	// ServiceType LoadBalancer services of the component are turned into
+	ServiceType string `json:"serviceType"`
+	// Ambassador marks the component as running Ambassador
+	Ambassador bool `json:"ambassador"`
+}
+
+// ReadProject returns the project file of the working directory, or nil if there is none
+func ReadProject() (*Project, error) {
+	b, err := ioutil.ReadFile(ProjectFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	p := &Project{}
+	if err := strictYAML(b, p); err != nil {
+		return nil, fmt.Errorf("%s: %s", ProjectFile, err)
+	}
+
+	return p, nil
+}
+
+// settings returns the project defaults with the component options kept in maps
+// applied. The ones kept in lists are applied by applyComponents once every settings
+// file is layered, as layering replaces lists.
+func (p *Project) settings() (*Settings, error) {
+	s := &Settings{}
+	if p.Defaults != nil {
+		b, err := json.Marshal(p.Defaults)
+		if err != nil {
+			return nil, err
+		}
+		if err := strictJSON(b, s); err != nil {
+			return nil, fmt.Errorf("%s: defaults: %s", ProjectFile, err)
+		}
+	}
+	if s.Manifests == "" {
+		s.Manifests = p.Manifests
+	}
+
+	for _, c := range p.componentNames() {
+		if o := p.Components[c]; o.ServiceType != "" {
+			if s.Services.Components == nil {
+				s.Services.Components = map[string]string{}
+			}
+			s.Services.Components[c] = o.ServiceType
+		}
+	}
+
+	return s, nil
+}
+
+// applyComponents adds the replicas and Ambassador component options to the layered
+// settings. Replicas go before every transform, so transforms of any settings file
+// take precedence.
+func (p *Project) applyComponents(s *Settings) {
+	var transforms []Transform
+	for _, c := range p.componentNames() {
+		o := p.Components[c]
+		if o.Replicas != nil {
+			transforms = append(transforms, Transform{Component: c, Replicas: o.Replicas})
+		}
+		if o.Ambassador && Find(s.Ambassador.Components, c) == -1 {
+			s.Ambassador.Components = append(s.Ambassador.Components, c)
+		}
+	}
+	s.Transforms = append(transforms, s.Transforms...)
+}
+
+func (p *Project) componentNames() []string {
+	var components []string
+	for c := range p.Components {
+		components = append(components, c)
+	}
+	sort.Strings(components)
+
+	return components
+}
+
+// Repos returns the components of every repo, and the file each repo is declared on
+func Repos() (map[string][]string, map[string]string, error) {
+	repos, sources, err := baseRepos()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	a, err := ioutil.ReadFile(RepositoriesComponentConfigPath())
+	if err != nil && !os.IsNotExist(err) {
+		return nil, nil, err
+	}
+	if err == nil {
+		var m map[string][]string
+		if err := json.Unmarshal(a, &m); err != nil {
+			return nil, nil, fmt.Errorf("%s: %s", RepositoriesComponentConfigPath(), err)
+		}
+		for repo, components := range m {
+			repos[repo] = components
+			sources[repo] = RepositoriesComponentConfigPath()
+		}
+	}
+
+	if len(repos) == 0 {
+		return nil, nil, fmt.Errorf("No repositories configured, declare them on %s or with config set-repo", ProjectFile)
+	}
+
+	return repos, sources, nil
+}
+
+// baseRepos returns the repos the user repositories file overrides: the ones of the
+// project file, overridden by the ones shared on the cluster
+func baseRepos() (map[string][]string, map[string]string, error) {
+	repos := map[string][]string{}
+	sources := map[string]string{}
+
+	p, err := ReadProject()
+	if err != nil {
+		return nil, nil, err
+	}
+	if p != nil {
+		for repo, components := range p.Repos {
+			repos[repo] = components
+			sources[repo] = ProjectFile
+		}
+	}
+
+	shared, err := ReadSharedCache()
+	if err != nil {
+		return nil, nil, err
+	}
+	if shared != nil {
+		for repo, components := range shared.Repos {
+			repos[repo] = components
+			sources[repo] = shared.Source
+		}
+	}
+
+	return repos, sources, nil
+}
+
+// ShowConfig writes the effective configuration, repos and settings, as YAML
+func ShowConfig(w io.Writer) error {
+	repos, sources, err := Repos()
+	if err != nil {
+		return err
+	}
+	s, err := ReadSettings()
+	if err != nil {
+		return err
+	}
+
+	var names []string
+	for repo := range repos {
+		names = append(names, repo)
+	}
+	sort.Strings(names)
+	for _, repo := range names {
+		fmt.Fprintf(w, "# %s from %s\n", repo, sources[repo])
+	}
+
+	b, err := yaml.Marshal(struct {
+		Repos    map[string][]string `json:"repos"`
+		Settings *Settings           `json:"settings"`
+	}{repos, s})
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+
+	return err
+}
+
+// ValidateConfig checks the project file and user settings have no unknown fields,
+// no component belongs to two repos and every component has a manifest
+func ValidateConfig() error {
+	var problems []string
+
+	repos, _, err := Repos()
+	if err != nil {
+		return err
+	}
+	s, err := ReadSettings()
+	if err != nil {
+		return err
+	}
+	if b, err := ioutil.ReadFile(SettingsPath()); err == nil {
+		if err := strictJSON(b, &Settings{}); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %s", SettingsPath(), err))
+		}
+	}
+
+	if _, err := s.PullSecrets.Source(); err != nil {
+		problems = append(problems, err.Error())
+	}
+
+	owners := map[string]string{}
+	var names []string
+	for repo := range repos {
+		names = append(names, repo)
+	}
+	sort.Strings(names)
+	for _, repo := range names {
+		for _, c := range repos[repo] {
+			if owner, ok := owners[c]; ok {
+				problems = append(problems, fmt.Sprintf("component %s belongs to repos %s and %s", c, owner, repo))
+				continue
+			}
+			owners[c] = repo
+
+			if !ManifestExists(s.Manifests, c) {
+				problems = append(problems, fmt.Sprintf("component %s of repo %s has no manifest %s/%s.yaml", c, repo, s.Manifests, c))
+			}
+		}
+	}
+
+	if p, _ := ReadProject(); p != nil {
+		for c := range p.Components {
+			if _, ok := owners[c]; !ok {
+				problems = append(problems, fmt.Sprintf("%s: options for component %s, which belongs to no repo", ProjectFile, c))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("Invalid configuration:\n  %s", strings.Join(problems, "\n  "))
+	}
+
+	return nil
+}
+
+// ManifestExists tells whether the deployment manifest of a component exists
+func ManifestExists(dir string, component string) bool {
+	for _, ext := range []string{".yaml", ".yml"} {
+		if _, err := os.Stat(dir + "/" + component + ext); err == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// strictYAML decodes YAML into v, failing on fields v does not have
+func strictYAML(b []byte, v interface{}) error {
+	j, err := yaml.YAMLToJSON(b)
+	if err != nil {
+		return err
+	}
+
+	return strictJSON(j, v)
+}
+
+func strictJSON(b []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+
+	return dec.Decode(v)
+}
+
+// mergeJSON merges src into dst, objects key by key and anything else replaced
+func mergeJSON(dst map[string]interface{}, src map[string]interface{}) {
+	for k, v := range src {
+		if s, ok := v.(map[string]interface{}); ok {
+			if d, ok := dst[k].(map[string]interface{}); ok {
+				mergeJSON(d, s)
+				continue
+			}
+		}
+		dst[k] = v
+	}
+}
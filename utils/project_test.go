@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMergeJSON(t *testing.T) {
+	tests := []struct {
+		dst  string
+		src  string
+		want string
+	}{
+		{`{}`, `{"a": 1}`, `{"a": 1}`},
+		{`{"a": 1, "b": 2}`, `{"a": 3}`, `{"a": 3, "b": 2}`},
+		// objects are merged key by key
+		{
+			`{"services": {"loadBalancer": "NodePort", "nodePortRange": "30000-31000"}}`,
+			`{"services": {"loadBalancer": "ClusterIP", "components": {"web": "NodePort"}}}`,
+			`{"services": {"loadBalancer": "ClusterIP", "nodePortRange": "30000-31000", "components": {"web": "NodePort"}}}`,
+		},
+		// arrays and values of another type are replaced
+		{`{"names": ["a", "b"]}`, `{"names": ["c"]}`, `{"names": ["c"]}`},
+		{`{"a": {"b": 1}}`, `{"a": "x"}`, `{"a": "x"}`},
+		{`{"a": "x"}`, `{"a": {"b": 1}}`, `{"a": {"b": 1}}`},
+		{`{"a": {"b": 1}}`, `{"a": null}`, `{"a": null}`},
+	}
+
+	for _, test := range tests {
+		dst, src, want := map[string]interface{}{}, map[string]interface{}{}, map[string]interface{}{}
+		for _, v := range []struct {
+			s string
+			m *map[string]interface{}
+		}{{test.dst, &dst}, {test.src, &src}, {test.want, &want}} {
+			if err := json.Unmarshal([]byte(v.s), v.m); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		mergeJSON(dst, src)
+		got, _ := json.Marshal(dst)
+		if w, _ := json.Marshal(want); string(got) != string(w) {
+			t.Errorf("%s + %s: got %s, want %s", test.dst, test.src, got, w)
+		}
+	}
+}
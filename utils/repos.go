@@ -0,0 +1,255 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+const (
+	lockTimeout = 10 * time.Second
+	// staleLock is the age of lock files left behind by crashed processes
+	staleLock = time.Minute
+)
+
+// AddComponents adds components to a repo, creating it if needed
+func AddComponents(repo string, components []string) error {
+	return editRepos(components, func(repos map[string][]string) error {
+		for _, c := range components {
+			if Find(repos[repo], c) == -1 {
+				repos[repo] = append(repos[repo], c)
+			}
+		}
+		return nil
+	}, repo)
+}
+
+// RemoveComponents removes components from a repo
+func RemoveComponents(repo string, components []string) error {
+	return editRepos(nil, func(repos map[string][]string) error {
+		current, ok := repos[repo]
+		if !ok {
+			return fmt.Errorf("Unknown repo %s", repo)
+		}
+
+		var kept []string
+		for _, c := range current {
+			if Find(components, c) == -1 {
+				kept = append(kept, c)
+			}
+		}
+		for _, c := range components {
+			if Find(current, c) == -1 {
+				return fmt.Errorf("Component %s does not belong to repo %s", c, repo)
+			}
+		}
+		repos[repo] = kept
+		return nil
+	}, repo)
+}
+
+// SetRepos replaces the components of the given repos at once, leaving the other
+// repos as they are, so components can move between the given repos
+func SetRepos(set map[string][]string) error {
+	var added, touched []string
+	for repo, components := range set {
+		added = append(added, components...)
+		touched = append(touched, repo)
+	}
+	sort.Strings(touched)
+
+	return editRepos(added, func(repos map[string][]string) error {
+		for repo, components := range set {
+			repos[repo] = components
+		}
+		return nil
+	}, touched...)
+}
+
+// RenameRepo renames a repo of the user repositories file
+func RenameRepo(repo string, to string) error {
+	return editRepos(nil, func(repos map[string][]string) error {
+		if _, ok := repos[repo]; !ok {
+			return fmt.Errorf("Unknown repo %s", repo)
+		}
+		if _, ok := repos[to]; ok {
+			return fmt.Errorf("Repo %s already exists", to)
+		}
+		repos[to] = repos[repo]
+		delete(repos, repo)
+		return nil
+	}, repo, to)
+}
+
+// editRepos applies an edit to the user repositories file while holding its lock.
+// The repos touched by the edit start from their effective components, so editing a
+// repo of the project file or the cluster overrides it on the user file. The result must not put a
+// component in two repos, and the added components must have manifests.
+func editRepos(added []string, edit func(map[string][]string) error, touched ...string) error {
+	path := RepositoriesComponentConfigPath()
+	unlock, err := lockFile(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	user := map[string][]string{}
+	b, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err == nil {
+		if err := json.Unmarshal(b, &user); err != nil {
+			return fmt.Errorf("%s: %s", path, err)
+		}
+	}
+
+	base, sources, err := baseRepos()
+	if err != nil {
+		return err
+	}
+
+	edited := map[string][]string{}
+	for repo, components := range base {
+		edited[repo] = components
+	}
+	for repo, components := range user {
+		edited[repo] = components
+	}
+	if err := edit(edited); err != nil {
+		return err
+	}
+
+	for _, repo := range touched {
+		_, inBase := base[repo]
+		components, ok := edited[repo]
+		switch {
+		case ok:
+			user[repo] = components
+		case inBase:
+			return fmt.Errorf("Repo %s is declared on %s, edit it there", repo, sources[repo])
+		default:
+			delete(user, repo)
+		}
+	}
+
+	if err := checkRepos(edited, added); err != nil {
+		return err
+	}
+
+	j, err := json.MarshalIndent(user, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(path, j, 0644)
+}
+
+// checkRepos refuses components in two repos and added components without manifests
+func checkRepos(repos map[string][]string, added []string) error {
+	var names []string
+	for repo := range repos {
+		names = append(names, repo)
+	}
+	sort.Strings(names)
+
+	owners := map[string]string{}
+	for _, repo := range names {
+		for _, c := range repos[repo] {
+			if owner, ok := owners[c]; ok {
+				return fmt.Errorf("Component %s cannot belong to repos %s and %s", c, owner, repo)
+			}
+			owners[c] = repo
+		}
+	}
+
+	s, err := ReadSettings()
+	if err != nil {
+		return err
+	}
+	for _, c := range added {
+		if !ManifestExists(s.Manifests, c) {
+			return fmt.Errorf("Component %s has no manifest %s/%s.yaml", c, s.Manifests, c)
+		}
+	}
+
+	return nil
+}
+
+// lockFile takes the lock of a file, waiting for other processes holding it. It
+// returns the function releasing the lock.
+func lockFile(path string) (func(), error) {
+	lock := path + ".lock"
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		f, err := os.OpenFile(lock, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			fmt.Fprintf(f, "%d", os.Getpid())
+			f.Close()
+			return func() { os.Remove(lock) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, err
+		}
+
+		if info, err := os.Stat(lock); err == nil && time.Since(info.ModTime()) > staleLock {
+			breakStaleLock(lock, info.ModTime())
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("Could not lock %s, remove %s if no other k8-cid is running", path, lock)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// breakStaleLock removes a lock left behind by a process that died holding it. It is
+// only removed while holding the breaker lock, and if it still is the stale one, so
+// processes finding the same stale lock never remove a fresh lock taken in between.
+func breakStaleLock(lock string, modTime time.Time) {
+	breaker := lock + ".break"
+	f, err := os.OpenFile(breaker, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		// Left by a process that died breaking the lock
+		if info, err := os.Stat(breaker); err == nil && time.Since(info.ModTime()) > staleLock {
+			os.Remove(breaker)
+		}
+		return
+	}
+	f.Close()
+	defer os.Remove(breaker)
+
+	if info, err := os.Stat(lock); err == nil && info.ModTime().Equal(modTime) {
+		os.Remove(lock)
+	}
+}
+
+// writeFileAtomic writes a file through a temporary file renamed over it, so readers
+// never see it half written
+func writeFileAtomic(path string, b []byte, perm os.FileMode) error {
+	f, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(f.Name(), perm); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), path)
+}
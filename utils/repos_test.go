@@ -0,0 +1,165 @@
+package utils
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testProject runs a test from a project directory declaring juno, with manifests for
+// the given components and its own home. It returns the function restoring the
+// working directory and home.
+func testProject(t *testing.T, components ...string) func() {
+	dir, err := ioutil.TempDir("", "k8-cid-project")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "home", K8sCidWorkingDir), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, DefaultManifests), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range components {
+		if err := ioutil.WriteFile(filepath.Join(dir, DefaultManifests, c+".yaml"), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	project := "repos:\n  juno: [mercury, venus]\n"
+	if err := ioutil.WriteFile(filepath.Join(dir, ProjectFile), []byte(project), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	wd, _ := os.Getwd()
+	home := os.Getenv("HOME")
+	os.Chdir(dir)
+	os.Setenv("HOME", filepath.Join(dir, "home"))
+
+	return func() {
+		os.Chdir(wd)
+		os.Setenv("HOME", home)
+		os.RemoveAll(dir)
+	}
+}
+
+func TestSetRepos(t *testing.T) {
+	defer testProject(t, "mercury", "venus", "kronos")()
+
+	// venus moves from the repo of the project file to vulcan
+	if err := SetRepos(map[string][]string{"juno": {"mercury"}, "vulcan": {"kronos", "venus"}}); err != nil {
+		t.Fatal(err)
+	}
+	repos, sources, err := Repos()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string][]string{"juno": {"mercury"}, "vulcan": {"kronos", "venus"}}
+	if !reflect.DeepEqual(repos, want) {
+		t.Errorf("got %v, want %v", repos, want)
+	}
+	if sources["juno"] == ProjectFile {
+		t.Errorf("juno is still read from %s", ProjectFile)
+	}
+
+	err = SetRepos(map[string][]string{"vulcan": {"kronos", "mercury"}})
+	if err == nil || !strings.Contains(err.Error(), "Component mercury cannot belong to repos") {
+		t.Errorf("got %v, want mercury in two repos", err)
+	}
+	err = SetRepos(map[string][]string{"vulcan": {"cerberus"}})
+	if err == nil || !strings.Contains(err.Error(), "Component cerberus has no manifest") {
+		t.Errorf("got %v, want no manifest", err)
+	}
+}
+
+func TestEditReposConcurrently(t *testing.T) {
+	var components []string
+	for i := 0; i < 10; i++ {
+		components = append(components, fmt.Sprintf("hermes-%d", i))
+	}
+	defer testProject(t, components...)()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, len(components))
+	for _, c := range components {
+		wg.Add(1)
+		go func(c string) {
+			defer wg.Done()
+			errs <- AddComponents("vulcan", []string{c})
+		}(c)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	repos, _, err := Repos()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := append([]string{}, repos["vulcan"]...)
+	sort.Strings(got)
+	if !reflect.DeepEqual(got, components) {
+		t.Errorf("got %v, want every component added", got)
+	}
+}
+
+func TestLockFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "k8-cid-lock")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "repositories-components.json")
+
+	held, err := lockFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	released := make(chan time.Time, 1)
+	go func() {
+		time.Sleep(300 * time.Millisecond)
+		released <- time.Now()
+		held()
+	}()
+
+	// waits for the holder to release it
+	unlock, err := lockFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if time.Now().Before(<-released) {
+		t.Error("lock taken while held")
+	}
+	unlock()
+	if _, err := os.Stat(path + ".lock"); !os.IsNotExist(err) {
+		t.Errorf("lock not released: %v", err)
+	}
+
+	// a lock left behind by a crashed process is broken
+	if err := ioutil.WriteFile(path+".lock", []byte("1"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-2 * staleLock)
+	if err := os.Chtimes(path+".lock", old, old); err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	unlock, err = lockFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unlock()
+	if time.Since(start) > lockTimeout/2 {
+		t.Errorf("stale lock broken after %s", time.Since(start))
+	}
+}
@@ -0,0 +1,350 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	apiv1 "k8s.io/api/core/v1"
+)
+
+// Settings are the optional deployer settings, read from settings.json on the
+// k8s-cid working directory
+type Settings struct {
+	// Manifests is the directory of the component manifests
+	Manifests   string        `json:"manifests"`
+	PullSecrets PullSecrets   `json:"pullSecrets"`
+	Images      Images        `json:"images"`
+	Transforms  []Transform   `json:"transforms"`
+	Services    ServicePolicy `json:"services"`
+	Ambassador  Ambassador    `json:"ambassador"`
+	RBAC        RBAC          `json:"rbac"`
+	Validation  Validation    `json:"validation"`
+	Schema      Schema        `json:"schema"`
+	Gateway     Gateway       `json:"gateway"`
+	Ingress     Ingress       `json:"ingress"`
+	Shared      Shared        `json:"shared"`
+}
+
+// PullSecrets are copied from a source namespace into every environment namespace,
+// so images from private registries can be pulled
+type PullSecrets struct {
+	SourceNamespace string   `json:"sourceNamespace"`
+	Names           []string `json:"names"`
+}
+
+// Source returns the namespace the pull secrets are copied from, failing when there
+// are secrets to copy but no namespace to copy them from
+func (p PullSecrets) Source() (string, error) {
+	if len(p.Names) > 0 && p.SourceNamespace == "" {
+		return "", fmt.Errorf("Pull secrets %s have no source namespace, set pullSecrets.sourceNamespace or -pull-secrets-from", strings.Join(p.Names, ", "))
+	}
+
+	return p.SourceNamespace, nil
+}
+
+func SettingsPath() string {
+	return HomeDir() + K8sCidWorkingDir + "/settings.json"
+}
+
+// ReadSettings returns the saved settings layered over the settings shared on the
+// cluster, layered over the project file defaults, or empty ones if there are none
+func ReadSettings() (*Settings, error) {
+	s := &Settings{}
+
+	p, err := ReadProject()
+	if err != nil {
+		return nil, err
+	}
+	if p != nil {
+		if s, err = p.settings(); err != nil {
+			return nil, err
+		}
+	}
+
+	shared, err := ReadSharedCache()
+	if err != nil {
+		return nil, err
+	}
+	if shared != nil && shared.Settings != nil {
+		b, err := json.Marshal(shared.Settings)
+		if err != nil {
+			return nil, err
+		}
+		if s, err = layerSettings(s, b); err != nil {
+			return nil, fmt.Errorf("%s: %s", shared.Source, err)
+		}
+	}
+
+	a, err := ioutil.ReadFile(SettingsPath())
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if err == nil {
+		if s, err = layerSettings(s, a); err != nil {
+			return nil, fmt.Errorf("%s: %s", SettingsPath(), err)
+		}
+	}
+
+	if p != nil {
+		p.applyComponents(s)
+	}
+	if s.Manifests == "" {
+		s.Manifests = DefaultManifests
+	}
+
+	return s, nil
+}
+
+// layerSettings overrides the base settings with the ones of a settings file
+func layerSettings(base *Settings, b []byte) (*Settings, error) {
+	var user map[string]interface{}
+	if err := json.Unmarshal(b, &user); err != nil {
+		return nil, err
+	}
+
+	j, err := json.Marshal(base)
+	if err != nil {
+		return nil, err
+	}
+	merged := map[string]interface{}{}
+	if err := json.Unmarshal(j, &merged); err != nil {
+		return nil, err
+	}
+	mergeJSON(merged, user)
+
+	if j, err = json.Marshal(merged); err != nil {
+		return nil, err
+	}
+	s := &Settings{}
+	if err := json.Unmarshal(j, s); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Images configures how the container images of components are rewritten
+type Images struct {
+	TagRules     []TagRule     `json:"tagRules"`
+	RewriteRules []RewriteRule `json:"rewriteRules"`
+	// InsecureRegistries are reached over plain http when verifying images
+	InsecureRegistries []string `json:"insecureRegistries"`
+}
+
+// TagRule decides whether the matching containers and init containers get the
+// commit tag of their repo or keep the tag they are pinned to. Empty fields match
+// anything, Container and Image (the image name without tag) accept shell patterns.
+// The first matching rule applies. Without a matching rule images are retagged,
+// unless they are pinned to a digest.
+type TagRule struct {
+	Repo      string `json:"repo"`
+	Component string `json:"component"`
+	Container string `json:"container"`
+	Image     string `json:"image"`
+	Retag     bool   `json:"retag"`
+}
+
+const (
+	// RewritePrefix replaces the From prefix of image names with To
+	RewritePrefix = "prefix"
+	// RewriteRegistry moves images from the From registry to the To one
+	RewriteRegistry = "registry"
+	// RewriteRepository renames the From repository, the image name without registry, to To
+	RewriteRepository = "repository"
+)
+
+// RewriteRule changes where the images of containers and init containers are pulled
+// from, e.g. to use a mirror. Empty Repo and Component match anything. All matching
+// rules apply, in order, after the images are retagged.
+type RewriteRule struct {
+	Repo      string `json:"repo"`
+	Component string `json:"component"`
+	Type      string `json:"type"`
+	From      string `json:"from"`
+	To        string `json:"to"`
+}
+
+// Transform adapts production oriented deployments to throwaway environments.
+// Empty Repo and Component match every deployment. All matching transforms apply,
+// in order, once the manifests are decoded.
+type Transform struct {
+	Repo      string `json:"repo"`
+	Component string `json:"component"`
+	// RemoveNodeSelector drops the pod node selector, before NodeSelector is merged in
+	RemoveNodeSelector bool              `json:"removeNodeSelector"`
+	NodeSelector       map[string]string `json:"nodeSelector"`
+	// RemoveTolerations drops the pod tolerations, before Tolerations are added
+	RemoveTolerations bool               `json:"removeTolerations"`
+	Tolerations       []apiv1.Toleration `json:"tolerations"`
+	Replicas          *int32             `json:"replicas"`
+	// Strategy is the deployment strategy type, Recreate or RollingUpdate
+	Strategy string `json:"strategy"`
+	// RequestsScale multiplies the CPU and memory requests of every container,
+	// e.g. 0.5 halves them. Requests never go over their limits.
+	RequestsScale float64 `json:"requestsScale"`
+}
+
+// ServicePolicy decides the type LoadBalancer services get on environments, so
+// throwaway environments do not provision cloud load balancers
+type ServicePolicy struct {
+	// LoadBalancer is the type LoadBalancer services are turned into, NodePort or
+	// ClusterIP. Empty keeps them as they are.
+	LoadBalancer string `json:"loadBalancer"`
+	// Components overrides LoadBalancer by component
+	Components map[string]string `json:"components"`
+	// NodePortRange node ports are allocated from, 30000-32767 by default
+	NodePortRange string `json:"nodePortRange"`
+}
+
+// Ambassador configures how the Ambassador gateways of environments are scoped.
+// By default every Ambassador only watches its own namespace and only serves the
+// mappings annotated with the environment ambassador_id.
+type Ambassador struct {
+	// Unscoped leaves Ambassador deployments watching every namespace
+	Unscoped bool `json:"unscoped"`
+	// Components run Ambassador, on top of the ones running a datawire/ambassador image
+	Components []string `json:"components"`
+}
+
+// RBAC configures how the cluster roles of components are deployed
+type RBAC struct {
+	// DowngradeClusterRoles replaces cluster roles whose rules do not need cluster
+	// scope, and the cluster role bindings granting them, with a role and role
+	// binding on the environment namespace
+	DowngradeClusterRoles bool       `json:"downgradeClusterRoles"`
+	Policy                RBACPolicy `json:"policy"`
+}
+
+const (
+	// PolicyWarn reports policy violations and creates the environment anyway
+	PolicyWarn = "warn"
+	// PolicyDeny refuses to create environments violating the policy
+	PolicyDeny = "deny"
+	// PolicyOff skips the policy checks
+	PolicyOff = "off"
+)
+
+// RBACPolicy guards environments against granting too much: bindings to denied
+// cluster roles, wildcard verbs or resources, cluster-wide access to secrets and
+// privileged or hostPath pods
+type RBACPolicy struct {
+	// Enforcement is deny, warn or off. By default bindings to denied cluster roles
+	// are refused and the other violations reported.
+	Enforcement string `json:"enforcement"`
+	// DeniedClusterRoles may not be bound, cluster-admin by default
+	DeniedClusterRoles []string `json:"deniedClusterRoles"`
+	// Exempt components are not checked
+	Exempt []string `json:"exempt"`
+}
+
+const (
+	// GatewayMapping routes through a shared Ambassador, with Mapping annotations on
+	// a service of the gateway namespace
+	GatewayMapping = "mapping"
+	// GatewayIngress routes through a shared ingress controller, with an Ingress on
+	// the environment namespace
+	GatewayIngress = "ingress"
+	// IngressRewriteNginx strips route prefixes with the NGINX ingress controller annotations
+	IngressRewriteNginx = "nginx"
+	// IngressRewriteNone leaves route prefixes on the paths backends receive
+	IngressRewriteNone = "none"
+)
+
+// Gateway routes environments through one shared gateway instead of deploying a
+// gateway, and its load balancer, per environment. Routes go to every service of the
+// environment under the Prefix path and/or on the Host, where {env} is replaced with
+// the environment namespace, e.g. /{env}/ or {env}.preview.example.com.
+type Gateway struct {
+	Shared bool `json:"shared"`
+	// Kind of routing objects, mapping, the default, or ingress
+	Kind string `json:"kind"`
+	// Namespace of the shared gateway, where mappings are created
+	Namespace string `json:"namespace"`
+	// AmbassadorID of the shared Ambassador, if it has one
+	AmbassadorID string `json:"ambassadorID"`
+	// IngressClass of the shared ingress controller, if not the default one
+	IngressClass string `json:"ingressClass"`
+	// IngressRewrite strips the route prefixes before requests reach the services, as
+	// Ambassador does: nginx, the default, or none
+	IngressRewrite string `json:"ingressRewrite"`
+	// Repos making up the gateway, not deployed on shared gateway environments
+	Repos  []string `json:"repos"`
+	Prefix string   `json:"prefix"`
+	Host   string   `json:"host"`
+	// URL the shared gateway is reached at, e.g. https://preview.example.com, used to
+	// print the environment endpoints. Defaults to http://<Host>.
+	URL string `json:"url"`
+}
+
+// Ingress exposes the services annotated with k8-cid/ingress on hostnames of their
+// environment, e.g. mercury.{env}.{domain}, over TLS when a mode is set
+type Ingress struct {
+	Domain string `json:"domain"`
+	// Class of the ingress controller, if not the default one
+	Class string     `json:"class"`
+	TLS   IngressTLS `json:"tls"`
+}
+
+const (
+	// TLSLocalCA issues the environment certificate from a local CA
+	TLSLocalCA = "ca"
+	// TLSCertManager has cert-manager issue the environment certificate
+	TLSCertManager = "cert-manager"
+)
+
+// IngressTLS issues the certificate of the environment hostnames, stored as a Secret
+// of the environment namespace
+type IngressTLS struct {
+	// Mode is ca or cert-manager, ingresses are plain http if empty
+	Mode string `json:"mode"`
+	// CACert and CAKey are the PEM files of the local CA
+	CACert string `json:"caCert"`
+	CAKey  string `json:"caKey"`
+	// Validity of local CA certificates, e.g. 720h, the default
+	Validity string `json:"validity"`
+	// Issuer and IssuerKind reference the cert-manager issuer, a ClusterIssuer by default
+	Issuer     string `json:"issuer"`
+	IssuerKind string `json:"issuerKind"`
+	// APIVersion of cert-manager Certificates, certmanager.k8s.io/v1alpha1 by default
+	APIVersion string `json:"apiVersion"`
+}
+
+// Validation rules are evaluated against every generated object before create. Rules
+// are listed inline or in YAML files.
+type Validation struct {
+	Rules []Rule   `json:"rules"`
+	Files []string `json:"files"`
+}
+
+// Rule is a CEL expression every object of the given kinds must satisfy. Expressions
+// see the object as object, the component and repo it belongs to, and the namespace of
+// the environment as environment, e.g.
+// every deployment has readiness probes, and no :latest images outside the public repo:
+//
+//	object.spec.template.spec.containers.all(c, has(c.readinessProbe))
+//	repo == "public" || object.spec.template.spec.containers.all(c, !c.image.endsWith(":latest"))
+type Rule struct {
+	Name string `json:"name"`
+	// Kinds the rule applies to, every kind if empty
+	Kinds      []string `json:"kinds"`
+	Expression string   `json:"expression"`
+	Message    string   `json:"message"`
+	// Severity is warn, the default, or fail
+	Severity string `json:"severity"`
+}
+
+// Schema configures how component manifests are checked. Manifests are decoded
+// strictly and validated against the Kubernetes OpenAPI schema of the cluster, or
+// the one of the API types k8-cid is built with when the cluster does not serve it.
+type Schema struct {
+	// Lenient decoding drops unknown fields instead of failing
+	Lenient bool `json:"lenient"`
+	// File is an offline copy of the OpenAPI v2 spec, e.g. saved with
+	// kubectl get --raw /openapi/v2, used instead of the live cluster one
+	File string `json:"file"`
+	// Skip the OpenAPI validation
+	Skip bool `json:"skip"`
+}
@@ -0,0 +1,190 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+
+	"github.com/ghodss/yaml"
+)
+
+// DefaultSharedName is the name of the ConfigMap of the shared configuration
+const DefaultSharedName = "k8-cid-config"
+
+// ConfigMap keys of the shared configuration
+const (
+	SharedReposKey    = "repos.json"
+	SharedSettingsKey = "settings.json"
+)
+
+// Shared locates the configuration a team shares on the cluster: the component
+// mapping and settings published on a ConfigMap of a control namespace. It is
+// cached locally and overridden by the user repositories and settings files.
+type Shared struct {
+	// Namespace of the ConfigMap, nothing is shared if empty
+	Namespace string `json:"namespace"`
+	// Name of the ConfigMap, k8-cid-config by default
+	Name string `json:"name"`
+}
+
+// ConfigMapName returns the name of the shared ConfigMap
+func (s Shared) ConfigMapName() string {
+	if s.Name == "" {
+		return DefaultSharedName
+	}
+
+	return s.Name
+}
+
+// SharedConfig is the local cache of the shared configuration
+type SharedConfig struct {
+	// Cluster is the API server the configuration was read from
+	Cluster string `json:"cluster"`
+	// Source is the ConfigMap the configuration was read from, as namespace/name
+	Source   string                 `json:"source"`
+	Repos    map[string][]string    `json:"repos"`
+	Settings map[string]interface{} `json:"settings"`
+}
+
+// sharedCluster is the API server of the current kubeconfig context, whose shared
+// configuration is layered in. Nothing is shared until it is known.
+var sharedCluster string
+
+// UseCluster sets the API server of the current kubeconfig context
+func UseCluster(server string) {
+	sharedCluster = server
+}
+
+// SharedCachePath is the cache of the shared configuration of the current cluster,
+// every cluster has its own
+func SharedCachePath() string {
+	sum := sha256.Sum256([]byte(sharedCluster))
+
+	return HomeDir() + K8sCidWorkingDir + "/shared-config-" + hex.EncodeToString(sum[:])[:12] + ".json"
+}
+
+// ReadSharedCache returns the cached shared configuration of the current cluster, or
+// nil if there is none
+func ReadSharedCache() (*SharedConfig, error) {
+	if sharedCluster == "" {
+		return nil, nil
+	}
+
+	b, err := ioutil.ReadFile(SharedCachePath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	c := &SharedConfig{}
+	if err := json.Unmarshal(b, c); err != nil {
+		return nil, fmt.Errorf("%s: %s", SharedCachePath(), err)
+	}
+
+	return c, nil
+}
+
+// WriteSharedCache caches the shared configuration of the current cluster, removing
+// the cache when c is nil
+func WriteSharedCache(c *SharedConfig) error {
+	if sharedCluster == "" {
+		return fmt.Errorf("No cluster to cache the shared configuration of")
+	}
+	if c == nil {
+		if err := os.Remove(SharedCachePath()); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	c.Cluster = sharedCluster
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(SharedCachePath(), b, 0644)
+}
+
+// OpenAPICachePath is the cache of the OpenAPI spec the current cluster serves at a
+// server version, every cluster has its own
+func OpenAPICachePath(version string) string {
+	sum := sha256.Sum256([]byte(sharedCluster + "\n" + version))
+
+	return HomeDir() + K8sCidWorkingDir + "/openapi-" + hex.EncodeToString(sum[:])[:12] + ".json"
+}
+
+// ReadOpenAPICache returns the cached OpenAPI spec of the current cluster at a server
+// version, or nil if there is none
+func ReadOpenAPICache(version string) ([]byte, error) {
+	if sharedCluster == "" {
+		return nil, nil
+	}
+
+	b, err := ioutil.ReadFile(OpenAPICachePath(version))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	return b, err
+}
+
+// WriteOpenAPICache caches the OpenAPI spec of the current cluster at a server version.
+// Nothing is cached until the cluster is known.
+func WriteOpenAPICache(version string, b []byte) error {
+	if sharedCluster == "" {
+		return nil
+	}
+
+	return writeFileAtomic(OpenAPICachePath(version), b, 0644)
+}
+
+// PublishedConfig returns the configuration to publish. Only what is set explicitly is
+// published, so that neither zero values nor the settings of the publisher override
+// the project file for everyone: the repos that differ from the ones of the project
+// file and the settings of the given JSON or YAML file. Settings are nil without file.
+func PublishedConfig(settingsFile string) (map[string][]string, map[string]interface{}, error) {
+	repos, _, err := Repos()
+	if err != nil {
+		return nil, nil, err
+	}
+	p, err := ReadProject()
+	if err != nil {
+		return nil, nil, err
+	}
+	if p != nil {
+		for repo, components := range p.Repos {
+			if reflect.DeepEqual(repos[repo], components) {
+				delete(repos, repo)
+			}
+		}
+	}
+
+	if settingsFile == "" {
+		return repos, nil, nil
+	}
+
+	b, err := ioutil.ReadFile(settingsFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	j, err := yaml.YAMLToJSON(b)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %s", settingsFile, err)
+	}
+	if err := strictJSON(j, &Settings{}); err != nil {
+		return nil, nil, fmt.Errorf("%s: %s", settingsFile, err)
+	}
+	settings := map[string]interface{}{}
+	if err := json.Unmarshal(j, &settings); err != nil {
+		return nil, nil, fmt.Errorf("%s: %s", settingsFile, err)
+	}
+
+	return repos, settings, nil
+}
@@ -1,14 +1,20 @@
 package utils
 
 import (
-	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"os"
 )
 
 const CREATE_RESOURCE = "create"
 const DELETE_RESOURCE = "delete"
+const CONNECT_RESOURCE = "connect"
+const LOGS_RESOURCE = "logs"
+const COLLECT_RESOURCE = "collect"
+const RENDER_RESOURCE = "render"
+const VALIDATE_RESOURCE = "validate"
+const CONFIG_RESOURCE = "config"
+const LIST_RESOURCE = "list"
+const DESCRIBE_RESOURCE = "describe"
 const K8sCidWorkingDir = "/.k8s-cid"
 
 func HomeDir() string {
@@ -39,18 +45,13 @@ func RepositoriesComponentConfigPath() string {
 	return HomeDir() + K8sCidWorkingDir + "/repositories-components.json"
 }
 
+// ReadRepos returns the components of every repo, see Repos
 func ReadRepos() map[string][]string {
-	var m map[string][]string
-
-	a, err := ioutil.ReadFile(RepositoriesComponentConfigPath())
+	m, _, err := Repos()
 	if err != nil {
 		panic(err)
 	}
 
-	if err := json.Unmarshal(a, &m); err != nil {
-		panic(err)
-	}
-
 	return m
 }
 